@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"log"
 	"os"
 
@@ -12,15 +14,15 @@ func main() {
 	app := fx.New(
 		di.Module,
 		
-		fx.NopLogger,
+		fx.WithLogger(di.NewFxLogger),
 	)
 	
 	if err := app.Err(); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
 		log.Fatalf("Failed to initialize application: %v", err)
-		os.Exit(1)
 	}
-	
-	log.Println("Application initialized successfully")
 
 	app.Run()
-}
\ No newline at end of file
+}
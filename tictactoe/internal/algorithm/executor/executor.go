@@ -0,0 +1,197 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+
+	"tictactoe/internal/metrics"
+)
+
+var (
+	ErrSaturated = errors.New("search queue is full")
+	ErrClosed    = errors.New("executor is closed")
+)
+
+const minRetryAfter = time.Second
+
+type Options struct {
+	Workers   int
+	QueueSize int
+	Timeout   time.Duration
+}
+
+type job struct {
+	ctx      context.Context
+	task     func(ctx context.Context)
+	queuedAt time.Time
+	done     chan struct{}
+	panicked any
+}
+
+// Executor runs engine searches on a fixed set of workers. A caller first
+// reserves a slot (a worker or a place in the queue) and then either runs
+// exactly one task with it or releases it.
+type Executor struct {
+	jobs     chan *job
+	workers  int
+	capacity int
+	timeout  time.Duration
+	metrics  *metrics.Metrics
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	reserved int
+	running  int
+	closed   bool
+	average  time.Duration
+}
+
+func New(options Options, m *metrics.Metrics) *Executor {
+	if options.Workers <= 0 {
+		options.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	capacity := options.Workers + options.QueueSize
+	e := &Executor{
+		jobs:     make(chan *job, capacity),
+		workers:  options.Workers,
+		capacity: capacity,
+		timeout:  options.Timeout,
+		metrics:  m,
+	}
+
+	m.RegisterEngineQueue(e.Queued, e.Running)
+
+	for range options.Workers {
+		e.wg.Add(1)
+		go e.work()
+	}
+
+	return e
+}
+
+func (e *Executor) Reserve() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return ErrClosed
+	}
+	if e.reserved >= e.capacity {
+		e.metrics.EngineRejected()
+		return ErrSaturated
+	}
+
+	e.reserved++
+	return nil
+}
+
+func (e *Executor) Release() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reserved--
+}
+
+func (e *Executor) Run(ctx context.Context, task func(ctx context.Context)) error {
+	j := &job{
+		ctx:      ctx,
+		task:     task,
+		queuedAt: time.Now(),
+		done:     make(chan struct{}),
+	}
+
+	e.mu.Lock()
+	if e.closed {
+		e.reserved--
+		e.mu.Unlock()
+		return ErrClosed
+	}
+	e.jobs <- j
+	e.mu.Unlock()
+
+	<-j.done
+	if j.panicked != nil {
+		panic(j.panicked)
+	}
+	return nil
+}
+
+func (e *Executor) Saturated() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.reserved >= e.capacity
+}
+
+func (e *Executor) Queued() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.reserved - e.running
+}
+
+func (e *Executor) Running() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.running
+}
+
+// RetryAfter estimates how long it takes to work through the current queue.
+func (e *Executor) RetryAfter() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	queued := e.reserved - e.running
+	return max(e.average*time.Duration(queued/e.workers+1), minRetryAfter)
+}
+
+func (e *Executor) Close() {
+	e.mu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.jobs)
+	}
+	e.mu.Unlock()
+
+	e.wg.Wait()
+}
+
+func (e *Executor) work() {
+	defer e.wg.Done()
+
+	for j := range e.jobs {
+		e.execute(j)
+	}
+}
+
+func (e *Executor) execute(j *job) {
+	e.mu.Lock()
+	e.running++
+	e.mu.Unlock()
+
+	e.metrics.ObserveEngineQueueWait(time.Since(j.queuedAt))
+	started := time.Now()
+
+	defer func() {
+		j.panicked = recover()
+		duration := time.Since(started)
+
+		e.mu.Lock()
+		e.running--
+		e.reserved--
+		if e.average == 0 {
+			e.average = duration
+		} else {
+			e.average = (4*e.average + duration) / 5
+		}
+		e.mu.Unlock()
+
+		close(j.done)
+	}()
+
+	ctx, cancel := context.WithTimeout(j.ctx, e.timeout)
+	defer cancel()
+
+	j.task(ctx)
+}
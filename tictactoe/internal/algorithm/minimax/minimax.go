@@ -1,7 +1,15 @@
 package minimax
 
 import (
+    "context"
+    "time"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/trace"
+    "go.uber.org/zap"
     "tictactoe/internal/domain/model"
+    "tictactoe/internal/metrics"
+    "tictactoe/internal/tracing"
 )
 
 const (
@@ -9,12 +17,32 @@ const (
     MinScore = -1000
 )
 
+const cancelCheckInterval = 1024
+
+var tracer = otel.Tracer("tictactoe/internal/algorithm/minimax")
+
+type Options struct {
+    MaxSearchNodes int
+    MaxDepth       int
+}
+
 type Minimax struct {
     computerPlayer int
     humanPlayer    int
+    maxSearchNodes int
+    maxDepth       int
+    logger         *zap.Logger
+    metrics        *metrics.Metrics
+}
+
+type search struct {
+    *Minimax
+    ctx   context.Context
+    nodes int
+    err   error
 }
 
-func NewMinimax(computerPlayer int) *Minimax {
+func NewMinimax(computerPlayer int, options Options, logger *zap.Logger, m *metrics.Metrics) *Minimax {
     humanPlayer := 1
     if computerPlayer == 1 {
         humanPlayer = 2
@@ -23,26 +51,87 @@ func NewMinimax(computerPlayer int) *Minimax {
     return &Minimax{
         computerPlayer: computerPlayer,
         humanPlayer:    humanPlayer,
+        maxSearchNodes: options.MaxSearchNodes,
+        maxDepth:       options.MaxDepth,
+        logger:         logger,
+        metrics:        m,
     }
 }
 
-func (m *Minimax) FindBestMove(game *model.Game) (int, int) {
+func (m *Minimax) FindBestMove(ctx context.Context, game *model.Game) (int, int, error) {
+    ctx, span := tracer.Start(ctx, "Minimax.FindBestMove", trace.WithAttributes(
+        tracing.GameIDKey.String(game.ID.String()),
+        tracing.BoardSizeKey.Int(game.Size)))
+    
+    started := time.Now()
     gameCopy := game.DeepCopy()
+    s := &search{Minimax: m, ctx: ctx, err: ctx.Err()}
     
     bestScore := MinScore
     bestRow, bestCol := -1, -1
-    alpha := MinScore
-    beta := MaxScore
     
+    // Iterative deepening: every finished depth gives a move, so a search cut
+    // short by the deadline plays the deepest completed one.
+    maxDepth := m.searchDepth(m.countEmpty(gameCopy))
     priorityMoves := m.getPriorityMoves(gameCopy)
+    completedDepth := -1
+    
+    for depth := 0; depth <= maxDepth; depth++ {
+        row, col, score := s.searchRoot(gameCopy, priorityMoves, depth)
+        if s.err != nil {
+            break
+        }
+        bestRow, bestCol, bestScore = row, col, score
+        completedDepth = depth
+        
+        if row != -1 {
+            priorityMoves = moveFirst(priorityMoves, row, col)
+        }
+    }
     
-    for _, move := range priorityMoves {
+    if bestRow == -1 {
+        bestRow, bestCol = m.findFirstEmpty(gameCopy)
+    }
+    
+    duration := time.Since(started)
+    m.metrics.ObserveSearch(game.Size, s.nodes, duration, s.err != nil)
+    
+    span.SetAttributes(
+        tracing.SearchDepthKey.Int(completedDepth),
+        tracing.SearchNodesKey.Int(s.nodes),
+        tracing.SearchCancelledKey.Bool(s.err != nil))
+    tracing.End(span, s.err)
+    
+    m.logger.Debug("search finished",
+        zap.Stringer("game_id", game.ID),
+        zap.Int("size", game.Size),
+        zap.Int("depth", completedDepth),
+        zap.Int("nodes", s.nodes),
+        zap.Int("score", bestScore),
+        zap.Duration("duration", duration),
+        zap.Bool("cancelled", s.err != nil))
+    
+    return bestRow, bestCol, s.err
+}
+
+// searchRoot looks maxDepth plies ahead from every free cell, trying moves in
+// the given order.
+func (s *search) searchRoot(game *model.Game, moves [][2]int, maxDepth int) (int, int, int) {
+    bestScore := MinScore
+    bestRow, bestCol := -1, -1
+    alpha := MinScore
+    beta := MaxScore
+    
+    for _, move := range moves {
         row, col := move[0], move[1]
-        if gameCopy.Field.IsEmpty(row, col) {
-            currentGame := gameCopy.DeepCopy()
-            currentGame.MakeMove(row, col, m.computerPlayer)
+        if game.Field.IsEmpty(row, col) {
+            currentGame := game.DeepCopy()
+            currentGame.MakeMove(row, col, s.computerPlayer)
             
-            score := m.minimax(currentGame, 0, false, alpha, beta)
+            score := s.minimax(currentGame, 0, maxDepth, false, alpha, beta)
+            if s.err != nil {
+                break
+            }
             
             if score > bestScore {
                 bestScore = score
@@ -56,14 +145,23 @@ func (m *Minimax) FindBestMove(game *model.Game) (int, int) {
         }
     }
     
-    if bestRow == -1 {
-        return m.findFirstEmpty(gameCopy)
+    return bestRow, bestCol, bestScore
+}
+
+func (s *search) cancelled() bool {
+    if s.err == nil && s.nodes%cancelCheckInterval == 0 {
+        s.err = s.ctx.Err()
     }
-    
-    return bestRow, bestCol
+    return s.err != nil
 }
 
-func (m *Minimax) minimax(game *model.Game, depth int, isMaximizing bool, alpha, beta int) int {
+func (s *search) minimax(game *model.Game, depth, maxDepth int, isMaximizing bool, alpha, beta int) int {
+    s.nodes++
+    if s.cancelled() {
+        return 0
+    }
+    
+    m := s.Minimax
     winner := game.CheckWinner()
     if winner == m.computerPlayer {
         return MaxScore - depth
@@ -71,7 +169,7 @@ func (m *Minimax) minimax(game *model.Game, depth int, isMaximizing bool, alpha,
     if winner == m.humanPlayer {
         return depth - MaxScore
     }
-    if game.IsFull() {
+    if game.IsFull() || depth >= maxDepth {
         return 0
     }
     
@@ -83,7 +181,7 @@ func (m *Minimax) minimax(game *model.Game, depth int, isMaximizing bool, alpha,
                     gameCopy := game.DeepCopy()
                     gameCopy.MakeMove(i, j, m.computerPlayer)
                     
-                    score := m.minimax(gameCopy, depth+1, false, alpha, beta)
+                    score := s.minimax(gameCopy, depth+1, maxDepth, false, alpha, beta)
                     maxScore = max(maxScore, score)
                     alpha = max(alpha, score)
                     
@@ -102,7 +200,7 @@ func (m *Minimax) minimax(game *model.Game, depth int, isMaximizing bool, alpha,
                     gameCopy := game.DeepCopy()
                     gameCopy.MakeMove(i, j, m.humanPlayer)
                     
-                    score := m.minimax(gameCopy, depth+1, true, alpha, beta)
+                    score := s.minimax(gameCopy, depth+1, maxDepth, true, alpha, beta)
                     minScore = min(minScore, score)
                     beta = min(beta, score)
                     
@@ -145,6 +243,19 @@ func (m *Minimax) getPriorityMoves(game *model.Game) [][2]int {
     return moves
 }
 
+// moveFirst puts the best move of the previous depth first, so alpha-beta
+// cuts more of the next one.
+func moveFirst(moves [][2]int, row, col int) [][2]int {
+    ordered := make([][2]int, 0, len(moves))
+    ordered = append(ordered, [2]int{row, col})
+    for _, move := range moves {
+        if move != [2]int{row, col} {
+            ordered = append(ordered, move)
+        }
+    }
+    return ordered
+}
+
 func (m *Minimax) findFirstEmpty(game *model.Game) (int, int) {
     for i := 0; i < game.Size; i++ {
         for j := 0; j < game.Size; j++ {
@@ -156,6 +267,39 @@ func (m *Minimax) findFirstEmpty(game *model.Game) (int, int) {
     return -1, -1
 }
 
+func (m *Minimax) countEmpty(game *model.Game) int {
+    count := 0
+    for i := 0; i < game.Size; i++ {
+        for j := 0; j < game.Size; j++ {
+            if game.Field.IsEmpty(i, j) {
+                count++
+            }
+        }
+    }
+    return count
+}
+
+// searchDepth returns how many plies the search looks ahead at most. Without
+// configured limits the whole game tree is searched.
+func (m *Minimax) searchDepth(emptyCells int) int {
+    depth := emptyCells
+    if m.maxSearchNodes > 0 {
+        depth = 0
+        nodes := 1
+        for cells := emptyCells - 1; cells > 0; cells-- {
+            nodes *= cells
+            if nodes > m.maxSearchNodes {
+                break
+            }
+            depth++
+        }
+    }
+    if m.maxDepth > 0 && depth > m.maxDepth {
+        depth = m.maxDepth
+    }
+    return depth
+}
+
 func max(a, b int) int {
     if a > b {
         return a
@@ -0,0 +1,330 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	StorageMemory = "memory"
+	StorageFile   = "file"
+)
+
+const (
+	LogFormatJSON    = "json"
+	LogFormatConsole = "console"
+)
+
+const (
+	TracingNone   = "none"
+	TracingStdout = "stdout"
+	TracingFile   = "file"
+)
+
+const (
+	RateLimitKeyIP     = "ip"
+	RateLimitKeyUser   = "user"
+	RateLimitKeyAPIKey = "api_key"
+)
+
+const (
+	defaultLogLevel  = "info"
+	defaultLogFormat = LogFormatJSON
+
+	defaultTracingExporter    = TracingNone
+	defaultTracingFile        = "traces.jsonl"
+	defaultTracingSampleRatio = 1.0
+
+	defaultHTTPPort        = 8080
+	defaultReadTimeout     = 10 * time.Second
+	defaultWriteTimeout    = 10 * time.Second
+	defaultIdleTimeout     = 30 * time.Second
+	defaultShutdownTimeout = 5 * time.Second
+	defaultMaxBodyBytes    = 1 << 20
+
+	defaultGRPCPort = 9090
+
+	defaultAdminPort = 9100
+
+	defaultEngineMaxNodes      = 1000000
+	defaultEngineMaxDepth      = 0
+	defaultEngineWorkers       = 0
+	defaultEngineQueueSize     = 64
+	defaultEngineSearchTimeout = 5 * time.Second
+
+	defaultStorageBackend = StorageMemory
+	defaultStorageDir     = "data"
+
+	defaultCORSMethods = "GET,POST"
+	defaultCORSHeaders = "Content-Type,Authorization,Last-Event-ID,Cache-Control,X-Request-ID,Prefer,X-API-Key,Idempotency-Key"
+	defaultCORSExposed = "Content-Type,X-Request-ID,Retry-After,Location,Preference-Applied,Idempotent-Replayed"
+	defaultCORSMaxAge  = 10 * time.Minute
+
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyMaxKeys = 10000
+
+	defaultRateLimitKey         = RateLimitKeyUser
+	defaultRateLimitMaxClients  = 10000
+	defaultRateLimitCreate      = 10
+	defaultRateLimitCreateBurst = 5
+	defaultRateLimitMove        = 120
+	defaultRateLimitMoveBurst   = 20
+	defaultRateLimitRender      = 30
+	defaultRateLimitRenderBurst = 10
+
+	defaultTokenTTL = 24 * time.Hour
+
+	defaultMatchWaitTimeout  = 8 * time.Second
+	defaultMatchRatingWindow = 200
+
+	defaultWebhookEvents         = "GameFinished"
+	defaultWebhookMaxAttempts    = 5
+	defaultWebhookInitialBackoff = time.Second
+	defaultWebhookMaxBackoff     = time.Minute
+	defaultWebhookTimeout        = 5 * time.Second
+	defaultWebhookDeadLetter     = "webhooks-dead-letter.log"
+	defaultWebhookWorkers        = 4
+)
+
+type Config struct {
+	File string `yaml:"-"`
+
+	Log         LogConfig         `yaml:"log"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	HTTP        HTTPConfig        `yaml:"http"`
+	GRPC        GRPCConfig        `yaml:"grpc"`
+	Admin       AdminConfig       `yaml:"admin"`
+	Engine      EngineConfig      `yaml:"engine"`
+	Storage     StorageConfig     `yaml:"storage"`
+	CORS        CORSConfig        `yaml:"cors"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Matchmaking MatchmakingConfig `yaml:"matchmaking"`
+	Webhooks    WebhookConfig     `yaml:"webhooks"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter"`
+	File        string  `yaml:"file"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+type HTTPConfig struct {
+	Port            int           `yaml:"port"`
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	MaxBodyBytes    int64         `yaml:"max_body_bytes"`
+}
+
+type GRPCConfig struct {
+	Port int `yaml:"port"`
+}
+
+type AdminConfig struct {
+	Port int `yaml:"port"`
+}
+
+type EngineConfig struct {
+	MaxSearchNodes int           `yaml:"max_search_nodes"`
+	MaxDepth       int           `yaml:"max_depth"`
+	Workers        int           `yaml:"workers"`
+	QueueSize      int           `yaml:"queue_size"`
+	SearchTimeout  time.Duration `yaml:"search_timeout"`
+}
+
+type StorageConfig struct {
+	Backend  string `yaml:"backend"`
+	Dir      string `yaml:"dir"`
+	MaxGames int    `yaml:"max_games"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowedMethods   []string      `yaml:"allowed_methods"`
+	AllowedHeaders   []string      `yaml:"allowed_headers"`
+	ExposedHeaders   []string      `yaml:"exposed_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"`
+}
+
+type RateLimitConfig struct {
+	Key             string   `yaml:"key"`
+	APIKeys         []string `yaml:"api_keys"`
+	TrustedProxies  []string `yaml:"trusted_proxies"`
+	MaxClients      int      `yaml:"max_clients"`
+	CreatePerMinute int      `yaml:"create_per_minute"`
+	CreateBurst     int      `yaml:"create_burst"`
+	MovePerMinute   int      `yaml:"move_per_minute"`
+	MoveBurst       int      `yaml:"move_burst"`
+	RenderPerMinute int      `yaml:"render_per_minute"`
+	RenderBurst     int      `yaml:"render_burst"`
+}
+
+type IdempotencyConfig struct {
+	TTL     time.Duration `yaml:"ttl"`
+	MaxKeys int           `yaml:"max_keys"`
+}
+
+type AuthConfig struct {
+	SigningKey string        `yaml:"signing_key"`
+	TokenTTL   time.Duration `yaml:"token_ttl"`
+	RandomKey  bool          `yaml:"-"`
+}
+
+type MatchmakingConfig struct {
+	WaitTimeout  time.Duration `yaml:"wait_timeout"`
+	RatingWindow int           `yaml:"rating_window"`
+}
+
+type WebhookConfig struct {
+	URLs           []string      `yaml:"urls"`
+	Events         []string      `yaml:"events"`
+	Secret         string        `yaml:"secret"`
+	MaxAttempts    int           `yaml:"max_attempts"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+	Timeout        time.Duration `yaml:"timeout"`
+	Workers        int           `yaml:"workers"`
+	DeadLetterLog  string        `yaml:"dead_letter_log"`
+}
+
+func Default() *Config {
+	return &Config{
+		Log: LogConfig{
+			Level:  defaultLogLevel,
+			Format: defaultLogFormat,
+		},
+		Tracing: TracingConfig{
+			Exporter:    defaultTracingExporter,
+			File:        defaultTracingFile,
+			SampleRatio: defaultTracingSampleRatio,
+		},
+		HTTP: HTTPConfig{
+			Port:            defaultHTTPPort,
+			ReadTimeout:     defaultReadTimeout,
+			WriteTimeout:    defaultWriteTimeout,
+			IdleTimeout:     defaultIdleTimeout,
+			ShutdownTimeout: defaultShutdownTimeout,
+			MaxBodyBytes:    defaultMaxBodyBytes,
+		},
+		GRPC: GRPCConfig{
+			Port: defaultGRPCPort,
+		},
+		Admin: AdminConfig{
+			Port: defaultAdminPort,
+		},
+		Engine: EngineConfig{
+			MaxSearchNodes: defaultEngineMaxNodes,
+			MaxDepth:       defaultEngineMaxDepth,
+			Workers:        defaultEngineWorkers,
+			QueueSize:      defaultEngineQueueSize,
+			SearchTimeout:  defaultEngineSearchTimeout,
+		},
+		Storage: StorageConfig{
+			Backend: defaultStorageBackend,
+			Dir:     defaultStorageDir,
+		},
+		CORS: CORSConfig{
+			AllowedMethods: splitList(defaultCORSMethods),
+			AllowedHeaders: splitList(defaultCORSHeaders),
+			ExposedHeaders: splitList(defaultCORSExposed),
+			MaxAge:         defaultCORSMaxAge,
+		},
+		RateLimit: RateLimitConfig{
+			Key:             defaultRateLimitKey,
+			MaxClients:      defaultRateLimitMaxClients,
+			CreatePerMinute: defaultRateLimitCreate,
+			CreateBurst:     defaultRateLimitCreateBurst,
+			MovePerMinute:   defaultRateLimitMove,
+			MoveBurst:       defaultRateLimitMoveBurst,
+			RenderPerMinute: defaultRateLimitRender,
+			RenderBurst:     defaultRateLimitRenderBurst,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:     defaultIdempotencyTTL,
+			MaxKeys: defaultIdempotencyMaxKeys,
+		},
+		Auth: AuthConfig{
+			TokenTTL: defaultTokenTTL,
+		},
+		Matchmaking: MatchmakingConfig{
+			WaitTimeout:  defaultMatchWaitTimeout,
+			RatingWindow: defaultMatchRatingWindow,
+		},
+		Webhooks: WebhookConfig{
+			Events:         []string{defaultWebhookEvents},
+			MaxAttempts:    defaultWebhookMaxAttempts,
+			InitialBackoff: defaultWebhookInitialBackoff,
+			MaxBackoff:     defaultWebhookMaxBackoff,
+			Timeout:        defaultWebhookTimeout,
+			Workers:        defaultWebhookWorkers,
+			DeadLetterLog:  defaultWebhookDeadLetter,
+		},
+	}
+}
+
+func Load(args []string) (*Config, error) {
+	path, err := configPath(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
+		cfg.File = path
+	}
+
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := applyFlags(args, cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if cfg.Auth.SigningKey == "" {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %w", err)
+		}
+		cfg.Auth.SigningKey = hex.EncodeToString(key)
+		cfg.Auth.RandomKey = true
+	}
+
+	return cfg, nil
+}
+
+func configPath(args []string) (string, error) {
+	var path string
+	flags := newFlagSet(Default(), &path)
+	flags.SetOutput(os.Stderr)
+
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+
+	if path == "" {
+		path = os.Getenv(envConfigFile)
+	}
+
+	return path, nil
+}
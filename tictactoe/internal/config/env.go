@@ -0,0 +1,250 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	envConfigFile = "TICTACTOE_CONFIG"
+
+	envLogLevel  = "TICTACTOE_LOG_LEVEL"
+	envLogFormat = "TICTACTOE_LOG_FORMAT"
+
+	envTracingExporter    = "TICTACTOE_TRACING_EXPORTER"
+	envTracingFile        = "TICTACTOE_TRACING_FILE"
+	envTracingSampleRatio = "TICTACTOE_TRACING_SAMPLE_RATIO"
+
+	envHTTPPort        = "TICTACTOE_HTTP_PORT"
+	envReadTimeout     = "TICTACTOE_HTTP_READ_TIMEOUT"
+	envWriteTimeout    = "TICTACTOE_HTTP_WRITE_TIMEOUT"
+	envIdleTimeout     = "TICTACTOE_HTTP_IDLE_TIMEOUT"
+	envShutdownTimeout = "TICTACTOE_SHUTDOWN_TIMEOUT"
+	envMaxBodyBytes    = "TICTACTOE_HTTP_MAX_BODY_BYTES"
+
+	envGRPCPort = "TICTACTOE_GRPC_PORT"
+
+	envAdminPort = "TICTACTOE_ADMIN_PORT"
+
+	envEngineMaxNodes      = "TICTACTOE_ENGINE_MAX_NODES"
+	envEngineMaxDepth      = "TICTACTOE_ENGINE_MAX_DEPTH"
+	envEngineWorkers       = "TICTACTOE_ENGINE_WORKERS"
+	envEngineQueueSize     = "TICTACTOE_ENGINE_QUEUE_SIZE"
+	envEngineSearchTimeout = "TICTACTOE_ENGINE_SEARCH_TIMEOUT"
+
+	envStorageBackend  = "TICTACTOE_STORAGE"
+	envStorageDir      = "TICTACTOE_STORAGE_DIR"
+	envStorageMaxGames = "TICTACTOE_STORAGE_MAX_GAMES"
+
+	envCORSOrigins     = "TICTACTOE_CORS_ORIGINS"
+	envCORSMethods     = "TICTACTOE_CORS_METHODS"
+	envCORSHeaders     = "TICTACTOE_CORS_HEADERS"
+	envCORSExposed     = "TICTACTOE_CORS_EXPOSED_HEADERS"
+	envCORSCredentials = "TICTACTOE_CORS_CREDENTIALS"
+	envCORSMaxAge      = "TICTACTOE_CORS_MAX_AGE"
+
+	envRateLimitKey         = "TICTACTOE_RATE_LIMIT_KEY"
+	envRateLimitAPIKeys     = "TICTACTOE_RATE_LIMIT_API_KEYS"
+	envRateLimitProxies     = "TICTACTOE_RATE_LIMIT_TRUSTED_PROXIES"
+	envRateLimitMaxClients  = "TICTACTOE_RATE_LIMIT_MAX_CLIENTS"
+	envRateLimitCreate      = "TICTACTOE_RATE_LIMIT_CREATE"
+	envRateLimitCreateBurst = "TICTACTOE_RATE_LIMIT_CREATE_BURST"
+	envRateLimitMove        = "TICTACTOE_RATE_LIMIT_MOVE"
+	envRateLimitMoveBurst   = "TICTACTOE_RATE_LIMIT_MOVE_BURST"
+	envRateLimitRender      = "TICTACTOE_RATE_LIMIT_RENDER"
+	envRateLimitRenderBurst = "TICTACTOE_RATE_LIMIT_RENDER_BURST"
+
+	envIdempotencyTTL     = "TICTACTOE_IDEMPOTENCY_TTL"
+	envIdempotencyMaxKeys = "TICTACTOE_IDEMPOTENCY_MAX_KEYS"
+
+	envJwtSecret = "TICTACTOE_JWT_SECRET"
+	envTokenTTL  = "TICTACTOE_TOKEN_TTL"
+
+	envMatchWaitTimeout  = "TICTACTOE_MATCH_WAIT_TIMEOUT"
+	envMatchRatingWindow = "TICTACTOE_MATCH_RATING_WINDOW"
+
+	envWebhookURLs           = "TICTACTOE_WEBHOOK_URLS"
+	envWebhookEvents         = "TICTACTOE_WEBHOOK_EVENTS"
+	envWebhookSecret         = "TICTACTOE_WEBHOOK_SECRET"
+	envWebhookMaxAttempts    = "TICTACTOE_WEBHOOK_MAX_ATTEMPTS"
+	envWebhookInitialBackoff = "TICTACTOE_WEBHOOK_INITIAL_BACKOFF"
+	envWebhookMaxBackoff     = "TICTACTOE_WEBHOOK_MAX_BACKOFF"
+	envWebhookTimeout        = "TICTACTOE_WEBHOOK_TIMEOUT"
+	envWebhookWorkers        = "TICTACTOE_WEBHOOK_WORKERS"
+	envWebhookDeadLetter     = "TICTACTOE_WEBHOOK_DEAD_LETTER_LOG"
+)
+
+func applyEnv(cfg *Config) error {
+	ints := []struct {
+		name   string
+		target *int
+	}{
+		{envHTTPPort, &cfg.HTTP.Port},
+		{envGRPCPort, &cfg.GRPC.Port},
+		{envAdminPort, &cfg.Admin.Port},
+		{envStorageMaxGames, &cfg.Storage.MaxGames},
+		{envEngineMaxNodes, &cfg.Engine.MaxSearchNodes},
+		{envEngineMaxDepth, &cfg.Engine.MaxDepth},
+		{envEngineWorkers, &cfg.Engine.Workers},
+		{envEngineQueueSize, &cfg.Engine.QueueSize},
+		{envRateLimitMaxClients, &cfg.RateLimit.MaxClients},
+		{envRateLimitCreate, &cfg.RateLimit.CreatePerMinute},
+		{envRateLimitCreateBurst, &cfg.RateLimit.CreateBurst},
+		{envRateLimitMove, &cfg.RateLimit.MovePerMinute},
+		{envRateLimitMoveBurst, &cfg.RateLimit.MoveBurst},
+		{envRateLimitRender, &cfg.RateLimit.RenderPerMinute},
+		{envRateLimitRenderBurst, &cfg.RateLimit.RenderBurst},
+		{envIdempotencyMaxKeys, &cfg.Idempotency.MaxKeys},
+		{envMatchRatingWindow, &cfg.Matchmaking.RatingWindow},
+		{envWebhookMaxAttempts, &cfg.Webhooks.MaxAttempts},
+		{envWebhookWorkers, &cfg.Webhooks.Workers},
+	}
+	for _, item := range ints {
+		if err := intFromEnv(item.name, item.target); err != nil {
+			return err
+		}
+	}
+
+	durations := []struct {
+		name   string
+		target *time.Duration
+	}{
+		{envReadTimeout, &cfg.HTTP.ReadTimeout},
+		{envWriteTimeout, &cfg.HTTP.WriteTimeout},
+		{envIdleTimeout, &cfg.HTTP.IdleTimeout},
+		{envShutdownTimeout, &cfg.HTTP.ShutdownTimeout},
+		{envEngineSearchTimeout, &cfg.Engine.SearchTimeout},
+		{envCORSMaxAge, &cfg.CORS.MaxAge},
+		{envIdempotencyTTL, &cfg.Idempotency.TTL},
+		{envTokenTTL, &cfg.Auth.TokenTTL},
+		{envMatchWaitTimeout, &cfg.Matchmaking.WaitTimeout},
+		{envWebhookInitialBackoff, &cfg.Webhooks.InitialBackoff},
+		{envWebhookMaxBackoff, &cfg.Webhooks.MaxBackoff},
+		{envWebhookTimeout, &cfg.Webhooks.Timeout},
+	}
+	for _, item := range durations {
+		if err := durationFromEnv(item.name, item.target); err != nil {
+			return err
+		}
+	}
+
+	if value := os.Getenv(envMaxBodyBytes); value != "" {
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: must be an integer", envMaxBodyBytes)
+		}
+		cfg.HTTP.MaxBodyBytes = limit
+	}
+
+	if value := os.Getenv(envTracingSampleRatio); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: must be a number", envTracingSampleRatio)
+		}
+		cfg.Tracing.SampleRatio = ratio
+	}
+
+	bools := []struct {
+		name   string
+		target *bool
+	}{
+		{envCORSCredentials, &cfg.CORS.AllowCredentials},
+	}
+	for _, item := range bools {
+		if err := boolFromEnv(item.name, item.target); err != nil {
+			return err
+		}
+	}
+
+	stringFromEnv(envLogLevel, &cfg.Log.Level)
+	stringFromEnv(envLogFormat, &cfg.Log.Format)
+	stringFromEnv(envTracingExporter, &cfg.Tracing.Exporter)
+	stringFromEnv(envTracingFile, &cfg.Tracing.File)
+	stringFromEnv(envStorageBackend, &cfg.Storage.Backend)
+	stringFromEnv(envStorageDir, &cfg.Storage.Dir)
+	stringFromEnv(envRateLimitKey, &cfg.RateLimit.Key)
+	stringFromEnv(envJwtSecret, &cfg.Auth.SigningKey)
+	stringFromEnv(envWebhookSecret, &cfg.Webhooks.Secret)
+	stringFromEnv(envWebhookDeadLetter, &cfg.Webhooks.DeadLetterLog)
+
+	listFromEnv(envCORSOrigins, &cfg.CORS.AllowedOrigins)
+	listFromEnv(envCORSMethods, &cfg.CORS.AllowedMethods)
+	listFromEnv(envCORSHeaders, &cfg.CORS.AllowedHeaders)
+	listFromEnv(envCORSExposed, &cfg.CORS.ExposedHeaders)
+	listFromEnv(envRateLimitAPIKeys, &cfg.RateLimit.APIKeys)
+	listFromEnv(envRateLimitProxies, &cfg.RateLimit.TrustedProxies)
+	listFromEnv(envWebhookURLs, &cfg.Webhooks.URLs)
+	listFromEnv(envWebhookEvents, &cfg.Webhooks.Events)
+
+	return nil
+}
+
+func stringFromEnv(name string, target *string) {
+	if value, ok := os.LookupEnv(name); ok {
+		*target = value
+	}
+}
+
+func listFromEnv(name string, target *[]string) {
+	if value, ok := os.LookupEnv(name); ok {
+		*target = splitList(value)
+	}
+}
+
+func intFromEnv(name string, target *int) error {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: must be an integer", name)
+	}
+
+	*target = parsed
+	return nil
+}
+
+func boolFromEnv(name string, target *bool) error {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: must be a boolean", name)
+	}
+
+	*target = parsed
+	return nil
+}
+
+func durationFromEnv(name string, target *time.Duration) error {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	*target = parsed
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
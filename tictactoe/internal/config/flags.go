@@ -0,0 +1,88 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"strings"
+)
+
+type listValue struct {
+	target *[]string
+}
+
+func (v listValue) String() string {
+	if v.target == nil {
+		return ""
+	}
+	return strings.Join(*v.target, ",")
+}
+
+func (v listValue) Set(value string) error {
+	*v.target = splitList(value)
+	return nil
+}
+
+func newFlagSet(cfg *Config, configFile *string) *flag.FlagSet {
+	flags := flag.NewFlagSet("tictactoe", flag.ContinueOnError)
+
+	flags.StringVar(configFile, "config", "", "path to a YAML or JSON config file (env "+envConfigFile+")")
+
+	flags.StringVar(&cfg.Log.Level, "log-level", cfg.Log.Level, "log level: debug, info, warn or error")
+	flags.StringVar(&cfg.Log.Format, "log-format", cfg.Log.Format, "log format: json or console")
+
+	flags.StringVar(&cfg.Tracing.Exporter, "tracing-exporter", cfg.Tracing.Exporter, "trace exporter: none, stdout or file")
+	flags.StringVar(&cfg.Tracing.File, "tracing-file", cfg.Tracing.File, "OTLP JSON file for the file trace exporter")
+	flags.Float64Var(&cfg.Tracing.SampleRatio, "tracing-sample-ratio", cfg.Tracing.SampleRatio, "fraction of traces to sample (0..1)")
+
+	flags.IntVar(&cfg.HTTP.Port, "http-port", cfg.HTTP.Port, "HTTP port")
+	flags.DurationVar(&cfg.HTTP.ReadTimeout, "http-read-timeout", cfg.HTTP.ReadTimeout, "HTTP read timeout")
+	flags.DurationVar(&cfg.HTTP.WriteTimeout, "http-write-timeout", cfg.HTTP.WriteTimeout, "HTTP write timeout")
+	flags.DurationVar(&cfg.HTTP.IdleTimeout, "http-idle-timeout", cfg.HTTP.IdleTimeout, "HTTP idle timeout")
+	flags.DurationVar(&cfg.HTTP.ShutdownTimeout, "shutdown-timeout", cfg.HTTP.ShutdownTimeout, "graceful shutdown timeout")
+	flags.Int64Var(&cfg.HTTP.MaxBodyBytes, "http-max-body-bytes", cfg.HTTP.MaxBodyBytes, "maximum request body size in bytes")
+
+	flags.IntVar(&cfg.GRPC.Port, "grpc-port", cfg.GRPC.Port, "gRPC port")
+
+	flags.IntVar(&cfg.Admin.Port, "admin-port", cfg.Admin.Port, "admin port for /metrics (0 disables)")
+
+	flags.IntVar(&cfg.Engine.MaxSearchNodes, "engine-max-nodes", cfg.Engine.MaxSearchNodes, "search tree size limit used to pick the minimax depth (0 - no limit)")
+	flags.IntVar(&cfg.Engine.MaxDepth, "engine-max-depth", cfg.Engine.MaxDepth, "hard minimax depth limit (0 - no limit)")
+	flags.IntVar(&cfg.Engine.Workers, "engine-workers", cfg.Engine.Workers, "number of concurrent engine searches (0 - one per CPU)")
+	flags.IntVar(&cfg.Engine.QueueSize, "engine-queue-size", cfg.Engine.QueueSize, "number of engine searches allowed to wait for a worker")
+	flags.DurationVar(&cfg.Engine.SearchTimeout, "engine-search-timeout", cfg.Engine.SearchTimeout, "time limit for a single engine search")
+
+	flags.StringVar(&cfg.Storage.Backend, "storage", cfg.Storage.Backend, "storage backend: memory or file")
+	flags.StringVar(&cfg.Storage.Dir, "storage-dir", cfg.Storage.Dir, "data directory for the file storage backend")
+	flags.IntVar(&cfg.Storage.MaxGames, "storage-max-games", cfg.Storage.MaxGames, "maximum number of stored games (0 - no limit)")
+
+	flags.Var(listValue{&cfg.CORS.AllowedOrigins}, "cors-origins", "comma-separated list of allowed CORS origins or patterns (empty - same origin only)")
+	flags.Var(listValue{&cfg.CORS.AllowedMethods}, "cors-methods", "comma-separated list of methods allowed in CORS requests")
+	flags.Var(listValue{&cfg.CORS.AllowedHeaders}, "cors-headers", "comma-separated list of request headers allowed in CORS requests")
+	flags.Var(listValue{&cfg.CORS.ExposedHeaders}, "cors-exposed-headers", "comma-separated list of response headers exposed to CORS requests")
+	flags.BoolVar(&cfg.CORS.AllowCredentials, "cors-credentials", cfg.CORS.AllowCredentials, "allow credentialed CORS requests")
+	flags.DurationVar(&cfg.CORS.MaxAge, "cors-max-age", cfg.CORS.MaxAge, "how long browsers may cache preflight responses")
+
+	flags.DurationVar(&cfg.Idempotency.TTL, "idempotency-ttl", cfg.Idempotency.TTL, "how long responses are kept for Idempotency-Key replays")
+	flags.IntVar(&cfg.Idempotency.MaxKeys, "idempotency-max-keys", cfg.Idempotency.MaxKeys, "maximum number of stored idempotency keys")
+
+	flags.StringVar(&cfg.RateLimit.Key, "rate-limit-key", cfg.RateLimit.Key, "rate limit key: ip, user or api_key")
+	flags.Var(listValue{&cfg.RateLimit.APIKeys}, "rate-limit-api-keys", "comma-separated list of API keys accepted as rate limit keys")
+	flags.Var(listValue{&cfg.RateLimit.TrustedProxies}, "rate-limit-trusted-proxies", "comma-separated list of proxy IPs or CIDRs whose X-Forwarded-For is trusted")
+	flags.IntVar(&cfg.RateLimit.MaxClients, "rate-limit-max-clients", cfg.RateLimit.MaxClients, "number of clients tracked by the rate limiter")
+	flags.IntVar(&cfg.RateLimit.CreatePerMinute, "rate-limit-create", cfg.RateLimit.CreatePerMinute, "games a client may create per minute (0 - no limit)")
+	flags.IntVar(&cfg.RateLimit.CreateBurst, "rate-limit-create-burst", cfg.RateLimit.CreateBurst, "game creation burst size")
+	flags.IntVar(&cfg.RateLimit.MovePerMinute, "rate-limit-move", cfg.RateLimit.MovePerMinute, "moves a client may submit per minute (0 - no limit)")
+	flags.IntVar(&cfg.RateLimit.MoveBurst, "rate-limit-move-burst", cfg.RateLimit.MoveBurst, "move submission burst size")
+	flags.IntVar(&cfg.RateLimit.RenderPerMinute, "rate-limit-render", cfg.RateLimit.RenderPerMinute, "board images and replays a client may request per minute (0 - no limit)")
+	flags.IntVar(&cfg.RateLimit.RenderBurst, "rate-limit-render-burst", cfg.RateLimit.RenderBurst, "board image and replay burst size")
+
+	return flags
+}
+
+func applyFlags(args []string, cfg *Config) error {
+	var configFile string
+	flags := newFlagSet(cfg, &configFile)
+	flags.SetOutput(io.Discard)
+
+	return flags.Parse(args)
+}
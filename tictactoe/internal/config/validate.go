@@ -0,0 +1,117 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"path"
+	"slices"
+	"strings"
+)
+
+func (cfg *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(slices.Contains([]string{"debug", "info", "warn", "error"}, cfg.Log.Level),
+		"log.level: must be one of debug, info, warn, error")
+	check(cfg.Log.Format == LogFormatJSON || cfg.Log.Format == LogFormatConsole,
+		"log.format: must be %q or %q", LogFormatJSON, LogFormatConsole)
+
+	switch cfg.Tracing.Exporter {
+	case TracingNone, TracingStdout:
+	case TracingFile:
+		check(cfg.Tracing.File != "", "tracing.file: is required for the %s exporter", TracingFile)
+	default:
+		check(false, "tracing.exporter: must be %q, %q or %q", TracingNone, TracingStdout, TracingFile)
+	}
+	check(cfg.Tracing.SampleRatio >= 0 && cfg.Tracing.SampleRatio <= 1, "tracing.sample_ratio: must be between 0 and 1")
+
+	check(validPort(cfg.HTTP.Port), "http.port: must be a port number")
+	check(cfg.HTTP.ReadTimeout > 0, "http.read_timeout: must be positive")
+	check(cfg.HTTP.WriteTimeout > 0, "http.write_timeout: must be positive")
+	check(cfg.HTTP.IdleTimeout > 0, "http.idle_timeout: must be positive")
+	check(cfg.HTTP.ShutdownTimeout > 0, "http.shutdown_timeout: must be positive")
+	check(cfg.HTTP.MaxBodyBytes > 0, "http.max_body_bytes: must be positive")
+
+	check(validPort(cfg.GRPC.Port), "grpc.port: must be a port number")
+	check(cfg.GRPC.Port != cfg.HTTP.Port, "grpc.port: must differ from http.port")
+
+	check(cfg.Admin.Port == 0 || validPort(cfg.Admin.Port), "admin.port: must be a port number or 0")
+	check(cfg.Admin.Port == 0 || (cfg.Admin.Port != cfg.HTTP.Port && cfg.Admin.Port != cfg.GRPC.Port),
+		"admin.port: must differ from http.port and grpc.port")
+
+	check(cfg.Engine.MaxSearchNodes >= 0, "engine.max_search_nodes: must be non-negative")
+	check(cfg.Engine.MaxDepth >= 0, "engine.max_depth: must be non-negative")
+	check(cfg.Engine.Workers >= 0, "engine.workers: must be non-negative")
+	check(cfg.Engine.QueueSize >= 0, "engine.queue_size: must be non-negative")
+	check(cfg.Engine.SearchTimeout > 0, "engine.search_timeout: must be positive")
+	check(cfg.Engine.SearchTimeout <= cfg.HTTP.WriteTimeout/2,
+		"engine.search_timeout: must be at most half of http.write_timeout, so a synchronous AI move is answered in time")
+
+	switch cfg.Storage.Backend {
+	case StorageMemory:
+	case StorageFile:
+		check(cfg.Storage.Dir != "", "storage.dir: is required for the %s backend", StorageFile)
+	default:
+		check(false, "storage.backend: must be %q or %q", StorageMemory, StorageFile)
+	}
+	check(cfg.Storage.MaxGames >= 0, "storage.max_games: must be non-negative")
+
+	for _, origin := range cfg.CORS.AllowedOrigins {
+		_, err := path.Match(origin, "")
+		check(origin == "*" || (err == nil && (strings.HasPrefix(origin, "http://") || strings.HasPrefix(origin, "https://"))),
+			"cors.allowed_origins: %q must be \"*\" or an http(s) origin or pattern", origin)
+	}
+	check(!cfg.CORS.AllowCredentials || !slices.Contains(cfg.CORS.AllowedOrigins, "*"),
+		"cors.allow_credentials: cannot be used with the \"*\" origin")
+	check(len(cfg.CORS.AllowedMethods) > 0, "cors.allowed_methods: must not be empty")
+	for _, method := range cfg.CORS.AllowedMethods {
+		check(method == strings.ToUpper(method), "cors.allowed_methods: %q must be upper case", method)
+	}
+	check(cfg.CORS.MaxAge >= 0, "cors.max_age: must be non-negative")
+
+	check(slices.Contains([]string{RateLimitKeyIP, RateLimitKeyUser, RateLimitKeyAPIKey}, cfg.RateLimit.Key),
+		"rate_limit.key: must be %q, %q or %q", RateLimitKeyIP, RateLimitKeyUser, RateLimitKeyAPIKey)
+	check(cfg.RateLimit.Key != RateLimitKeyAPIKey || len(cfg.RateLimit.APIKeys) > 0,
+		"rate_limit.api_keys: must not be empty when rate_limit.key is %q", RateLimitKeyAPIKey)
+	for _, proxy := range cfg.RateLimit.TrustedProxies {
+		_, prefixErr := netip.ParsePrefix(proxy)
+		_, addrErr := netip.ParseAddr(proxy)
+		check(prefixErr == nil || addrErr == nil, "rate_limit.trusted_proxies: %q must be an IP address or CIDR", proxy)
+	}
+	check(cfg.RateLimit.MaxClients > 0, "rate_limit.max_clients: must be positive")
+	check(cfg.RateLimit.CreatePerMinute >= 0, "rate_limit.create_per_minute: must be non-negative")
+	check(cfg.RateLimit.CreatePerMinute == 0 || cfg.RateLimit.CreateBurst > 0, "rate_limit.create_burst: must be positive")
+	check(cfg.RateLimit.MovePerMinute >= 0, "rate_limit.move_per_minute: must be non-negative")
+	check(cfg.RateLimit.MovePerMinute == 0 || cfg.RateLimit.MoveBurst > 0, "rate_limit.move_burst: must be positive")
+	check(cfg.RateLimit.RenderPerMinute >= 0, "rate_limit.render_per_minute: must be non-negative")
+	check(cfg.RateLimit.RenderPerMinute == 0 || cfg.RateLimit.RenderBurst > 0, "rate_limit.render_burst: must be positive")
+
+	check(cfg.Idempotency.TTL > 0, "idempotency.ttl: must be positive")
+	check(cfg.Idempotency.MaxKeys > 0, "idempotency.max_keys: must be positive")
+
+	check(cfg.Auth.TokenTTL > 0, "auth.token_ttl: must be positive")
+
+	check(cfg.Matchmaking.WaitTimeout > 0, "matchmaking.wait_timeout: must be positive")
+	check(cfg.Matchmaking.WaitTimeout < cfg.HTTP.WriteTimeout,
+		"matchmaking.wait_timeout: must be less than http.write_timeout, so a player left alone in the queue gets the AI game in time")
+	check(cfg.Matchmaking.RatingWindow >= 0, "matchmaking.rating_window: must be non-negative")
+
+	check(len(cfg.Webhooks.URLs) == 0 || cfg.Webhooks.Secret != "", "webhooks.secret: is required when webhooks.urls is set")
+	check(cfg.Webhooks.MaxAttempts > 0, "webhooks.max_attempts: must be positive")
+	check(cfg.Webhooks.InitialBackoff > 0, "webhooks.initial_backoff: must be positive")
+	check(cfg.Webhooks.MaxBackoff >= cfg.Webhooks.InitialBackoff, "webhooks.max_backoff: must not be less than webhooks.initial_backoff")
+	check(cfg.Webhooks.Timeout > 0, "webhooks.timeout: must be positive")
+	check(cfg.Webhooks.Workers > 0, "webhooks.workers: must be positive")
+
+	return errors.Join(errs...)
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
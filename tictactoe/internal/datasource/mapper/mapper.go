@@ -18,16 +18,60 @@ func FromDsToDomain(model *dsModel.GameModel) (*domainModel.Game, error) {
 		return nil, fmt.Errorf("bad UUID")
 	}
 
+	ownerID, err := parseOptionalUUID(model.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("bad owner UUID")
+	}
+
+	opponentID, err := parseOptionalUUID(model.OpponentID)
+	if err != nil {
+		return nil, fmt.Errorf("bad opponent UUID")
+	}
+
+	drawOfferedBy, err := parseOptionalUUID(model.DrawOfferedBy)
+	if err != nil {
+		return nil, fmt.Errorf("bad draw offer UUID")
+	}
+
+	aiJobID, err := parseOptionalUUID(model.AIJobID)
+	if err != nil {
+		return nil, fmt.Errorf("bad AI job UUID")
+	}
+
 	var field domainModel.GameField
 
 	if err := json.Unmarshal([]byte(model.Field), &field); err != nil {
 		return nil, fmt.Errorf("cant parse json field")
 	}
 
+	var moves []domainModel.Move
+
+	if model.Moves != "" {
+		if err := json.Unmarshal([]byte(model.Moves), &moves); err != nil {
+			return nil, fmt.Errorf("cant parse json moves")
+		}
+	}
+
+	var webhooks []domainModel.Webhook
+
+	if model.Webhooks != "" {
+		if err := json.Unmarshal([]byte(model.Webhooks), &webhooks); err != nil {
+			return nil, fmt.Errorf("cant parse json webhooks")
+		}
+	}
+
 	return &domainModel.Game{
 		ID: id,
+		OwnerID: ownerID,
+		OpponentID: opponentID,
+		Mode: model.Mode,
+		DrawOfferedBy: drawOfferedBy,
+		Webhooks: webhooks,
 		Field: field,
+		Moves: moves,
 		State: model.State,
+		AIJobID: aiJobID,
+		Version: model.Version,
 		PlayerTurn: model.PlayerTurn,
 		Size: model.Size,
 		CreatedAt: model.CreatedAt,
@@ -45,13 +89,77 @@ func FromDomainToDs(game *domainModel.Game) (*dsModel.GameModel, error) {
 		return nil, fmt.Errorf("failed to marshal field: %w", err)
 	}
 	
+	var movesJSON []byte
+	if len(game.Moves) > 0 {
+		movesJSON, err = json.Marshal(game.Moves)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal moves: %w", err)
+		}
+	}
+	
+	var webhooksJSON []byte
+	if len(game.Webhooks) > 0 {
+		webhooksJSON, err = json.Marshal(game.Webhooks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal webhooks: %w", err)
+		}
+	}
+	
 	return &dsModel.GameModel{
 		ID:        game.ID.String(), 
+		OwnerID:   game.OwnerID.String(),
+		OpponentID: game.OpponentID.String(),
+		Mode:      game.Mode,
+		DrawOfferedBy: game.DrawOfferedBy.String(),
+		Webhooks:  string(webhooksJSON),
 		Field:     string(fieldJSON),
+		Moves:     string(movesJSON),
 		State:     game.State,
+		AIJobID:   game.AIJobID.String(),
+		Version:   game.Version,
 		PlayerTurn: game.PlayerTurn,
 		Size:      game.Size,
 		CreatedAt: game.CreatedAt,
 		UpdatedAt: game.UpdatedAt,
 	}, nil
+}
+
+func FromUserDsToDomain(model *dsModel.UserModel) (*domainModel.User, error) {
+	if model == nil {
+		return nil, fmt.Errorf("model is nil")
+	}
+
+	id, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("bad UUID")
+	}
+
+	return &domainModel.User{
+		ID:           id,
+		Login:        model.Login,
+		PasswordHash: model.PasswordHash,
+		Rating:       model.Rating,
+		CreatedAt:    model.CreatedAt,
+	}, nil
+}
+
+func FromUserDomainToDs(user *domainModel.User) (*dsModel.UserModel, error) {
+	if user == nil {
+		return nil, fmt.Errorf("user is nil")
+	}
+
+	return &dsModel.UserModel{
+		ID:           user.ID.String(),
+		Login:        user.Login,
+		PasswordHash: user.PasswordHash,
+		Rating:       user.Rating,
+		CreatedAt:    user.CreatedAt,
+	}, nil
+}
+
+func parseOptionalUUID(value string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(value)
 }
\ No newline at end of file
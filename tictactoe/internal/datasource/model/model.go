@@ -4,10 +4,26 @@ import "time"
 
 type GameModel struct {
 	ID        string
+	OwnerID   string
+	OpponentID string
+	Mode      string
+	DrawOfferedBy string
+	Webhooks  string
 	Field     string
+	Moves     string
 	State     string
+	AIJobID   string
+	Version   int
 	PlayerTurn int
 	Size      int
 	CreatedAt time.Time
 	UpdatedAt time.Time
+}
+
+type UserModel struct {
+	ID           string
+	Login        string
+	PasswordHash string
+	Rating       int
+	CreatedAt    time.Time
 }
\ No newline at end of file
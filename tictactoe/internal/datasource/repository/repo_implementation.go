@@ -2,21 +2,40 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 	"tictactoe/internal/domain/model"
 	"tictactoe/internal/datasource/mapper"
+	"tictactoe/internal/logging"
+	"tictactoe/internal/metrics"
+	"tictactoe/internal/tracing"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
+var tracer = otel.Tracer("tictactoe/internal/datasource/repository")
+
 type GameRepositoryImpl struct {
 	storage *GameStorage
+	logger  *zap.Logger
+	metrics *metrics.Metrics
 }
 
-func NewGameRepo(storage *GameStorage) GameRepository {
-	return &GameRepositoryImpl{storage: storage}
+func NewGameRepo(storage *GameStorage, logger *zap.Logger, m *metrics.Metrics) GameRepository {
+	return &GameRepositoryImpl{storage: storage, logger: logger, metrics: m}
 }
 
-func (r *GameRepositoryImpl) Save(ctx context.Context, game *model.Game) error {
+func (r *GameRepositoryImpl) Save(ctx context.Context, game *model.Game) (err error) {
+	defer r.metrics.ObserveRepository("games", "save", time.Now())
+	ctx, span := tracer.Start(ctx, "GameRepository.Save", trace.WithAttributes(
+		tracing.GameIDKey.String(game.ID.String()),
+		attribute.Int("game.version", game.Version)))
+	defer func() { tracing.End(span, err) }()
+	
 	if err := ctx.Err(); err != nil {
 		return err
 	}
@@ -26,12 +45,32 @@ func (r *GameRepositoryImpl) Save(ctx context.Context, game *model.Game) error {
 		return fmt.Errorf("failed to convert game to model: %w", err)
 	}
 	
-	r.storage.Save(gameModel)
+	started := time.Now()
+	logger := logging.FromContext(ctx, r.logger).With(zap.Stringer("game_id", game.ID))
+	
+	if err := r.storage.Save(gameModel); err != nil {
+		if errors.Is(err, model.ErrConflict) {
+			logger.Info("game save conflict", zap.Int("version", game.Version))
+		} else {
+			logger.Error("failed to save game", zap.Error(err))
+		}
+		return err
+	}
+	
+	game.Version = gameModel.Version
+	logger.Debug("game saved",
+		zap.Int("version", game.Version),
+		zap.String("state", game.State),
+		zap.Duration("duration", time.Since(started)))
 	
 	return nil
 }
 
-func (r *GameRepositoryImpl) Get(ctx context.Context, gameID uuid.UUID) (*model.Game, error) {
+func (r *GameRepositoryImpl) Get(ctx context.Context, gameID uuid.UUID) (game *model.Game, err error) {
+	defer r.metrics.ObserveRepository("games", "get", time.Now())
+	ctx, span := tracer.Start(ctx, "GameRepository.Get", trace.WithAttributes(tracing.GameIDKey.String(gameID.String())))
+	defer func() { tracing.End(span, err) }()
+	
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -41,10 +80,45 @@ func (r *GameRepositoryImpl) Get(ctx context.Context, gameID uuid.UUID) (*model.
 		return nil, err
 	}
 	
-	game, err := mapper.FromDsToDomain(gameModel)
+	game, err = mapper.FromDsToDomain(gameModel)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert model to game: %w", err)
 	}
 	
 	return game, nil
-}
\ No newline at end of file
+}
+
+func (r *GameRepositoryImpl) GetByPlayer(ctx context.Context, playerID uuid.UUID) (games []*model.Game, err error) {
+	defer r.metrics.ObserveRepository("games", "get_by_player", time.Now())
+	ctx, span := tracer.Start(ctx, "GameRepository.GetByPlayer", trace.WithAttributes(tracing.UserIDKey.String(playerID.String())))
+	defer func() { tracing.End(span, err) }()
+	
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	gameModels := r.storage.GetByPlayer(playerID.String())
+	
+	games = make([]*model.Game, 0, len(gameModels))
+	for _, gameModel := range gameModels {
+		game, err := mapper.FromDsToDomain(gameModel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to game: %w", err)
+		}
+		games = append(games, game)
+	}
+	
+	return games, nil
+}
+
+func (r *GameRepositoryImpl) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
+	return r.storage.Ping()
+}
+
+func (r *GameRepositoryImpl) Capacity() (games, maxGames int) {
+	return r.storage.Capacity()
+}
@@ -10,4 +10,10 @@ type GameRepository interface {
 	Save(ctx context.Context, game *model.Game) error
 	
 	Get(ctx context.Context, gameID uuid.UUID) (*model.Game, error)
+	
+	GetByPlayer(ctx context.Context, playerID uuid.UUID) ([]*model.Game, error)
+	
+	Ping(ctx context.Context) error
+	
+	Capacity() (games, maxGames int)
 }
\ No newline at end of file
@@ -6,25 +6,125 @@ import (
 	"fmt"
 
 	"tictactoe/internal/datasource/model"
+	domainModel "tictactoe/internal/domain/model"
 )
 
 type GameStorage struct {
-	storage sync.Map
+	storage  sync.Map
+	mu       sync.Mutex
+	file     *fileSnapshot
+	count    int
+	maxGames int
 }
 
-func NewGameStorage() *GameStorage {
-	return &GameStorage{storage: sync.Map{}}
+func NewGameStorage(maxGames int) *GameStorage {
+	return &GameStorage{storage: sync.Map{}, maxGames: maxGames}
 }
 
-func (storage *GameStorage) Save(game *model.GameModel) {
+func NewFileGameStorage(path string, maxGames int) (*GameStorage, error) {
+	file, err := newFileSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var games []*model.GameModel
+	if err := file.load(&games); err != nil {
+		return nil, err
+	}
+
+	storage := &GameStorage{file: file, count: len(games), maxGames: maxGames}
+	for _, game := range games {
+		storage.storage.Store(game.ID, game)
+	}
+
+	return storage, nil
+}
+
+func (storage *GameStorage) Save(game *model.GameModel) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	stored, exists := storage.storage.Load(game.ID)
+	if exists {
+		if current, ok := stored.(*model.GameModel); ok && current.Version != game.Version {
+			return fmt.Errorf("%w: game was modified concurrently", domainModel.ErrConflict)
+		}
+	} else {
+		if storage.full() {
+			return fmt.Errorf("%w: limit of %d games reached", domainModel.ErrStorageFull, storage.maxGames)
+		}
+		storage.count++
+	}
+
+	game.Version++
 	storage.storage.Store(game.ID, game)
+
+	if err := storage.persist(); err != nil {
+		game.Version--
+		if exists {
+			storage.storage.Store(game.ID, stored)
+		} else {
+			storage.storage.Delete(game.ID)
+			storage.count--
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (storage *GameStorage) Ping() error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	// A full storage still serves existing games; only creating new ones fails.
+	if storage.file != nil {
+		return storage.file.probe()
+	}
+
+	return nil
+}
+
+// Capacity reports how many games are stored and the limit (0 - unlimited).
+func (storage *GameStorage) Capacity() (games, maxGames int) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	return storage.count, storage.maxGames
+}
+
+func (storage *GameStorage) full() bool {
+	return storage.maxGames > 0 && storage.count >= storage.maxGames
+}
+
+func (storage *GameStorage) Flush() error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	return storage.persist()
+}
+
+func (storage *GameStorage) persist() error {
+	if storage.file == nil {
+		return nil
+	}
+
+	var games []*model.GameModel
+	storage.storage.Range(func(_, value any) bool {
+		if game, ok := value.(*model.GameModel); ok {
+			games = append(games, game)
+		}
+		return true
+	})
+
+	return storage.file.save(games)
 }
 
 func (storage *GameStorage) Get(gameID string) (*model.GameModel, error) {
 	gameAsInterface, exists := storage.storage.Load(gameID)
 
 	if !exists {
-		return  nil, fmt.Errorf("cant find game by this ID")
+		return nil, domainModel.ErrGameNotFound
 	}
 
 	game, ok := gameAsInterface.(*model.GameModel)
@@ -34,4 +134,29 @@ func (storage *GameStorage) Get(gameID string) (*model.GameModel, error) {
 	}
 
 	return game, nil
+}
+
+func (storage *GameStorage) CountInProgress() int {
+	count := 0
+	storage.storage.Range(func(_, value any) bool {
+		if game, ok := value.(*model.GameModel); ok && (game.State == domainModel.StateInProgress || game.State == domainModel.StateAIThinking) {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+func (storage *GameStorage) GetByPlayer(playerID string) []*model.GameModel {
+	var games []*model.GameModel
+
+	storage.storage.Range(func(_, value any) bool {
+		game, ok := value.(*model.GameModel)
+		if ok && (game.OwnerID == playerID || game.OpponentID == playerID) {
+			games = append(games, game)
+		}
+		return true
+	})
+
+	return games
 }
\ No newline at end of file
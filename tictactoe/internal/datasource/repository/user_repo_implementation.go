@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"tictactoe/internal/datasource/mapper"
+	dsModel "tictactoe/internal/datasource/model"
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/logging"
+	"tictactoe/internal/metrics"
+	"tictactoe/internal/tracing"
+)
+
+type UserRepositoryImpl struct {
+	storage *UserStorage
+	logger  *zap.Logger
+	metrics *metrics.Metrics
+}
+
+func NewUserRepo(storage *UserStorage, logger *zap.Logger, m *metrics.Metrics) UserRepository {
+	return &UserRepositoryImpl{storage: storage, logger: logger, metrics: m}
+}
+
+func (r *UserRepositoryImpl) Create(ctx context.Context, user *model.User) (err error) {
+	defer r.metrics.ObserveRepository("users", "create", time.Now())
+	ctx, span := tracer.Start(ctx, "UserRepository.Create", trace.WithAttributes(tracing.UserIDKey.String(user.ID.String())))
+	defer func() { tracing.End(span, err) }()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	userModel, err := mapper.FromUserDomainToDs(user)
+	if err != nil {
+		return fmt.Errorf("failed to convert user to model: %w", err)
+	}
+
+	if err := r.storage.Create(userModel); err != nil {
+		if !errors.Is(err, model.ErrLoginTaken) {
+			logging.FromContext(ctx, r.logger).Error("failed to create user",
+				zap.Stringer("user_id", user.ID), zap.Error(err))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryImpl) Get(ctx context.Context, userID uuid.UUID) (user *model.User, err error) {
+	defer r.metrics.ObserveRepository("users", "get", time.Now())
+	ctx, span := tracer.Start(ctx, "UserRepository.Get", trace.WithAttributes(tracing.UserIDKey.String(userID.String())))
+	defer func() { tracing.End(span, err) }()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	userModel, err := r.storage.Get(userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(userModel)
+}
+
+func (r *UserRepositoryImpl) GetByLogin(ctx context.Context, login string) (user *model.User, err error) {
+	defer r.metrics.ObserveRepository("users", "get_by_login", time.Now())
+	ctx, span := tracer.Start(ctx, "UserRepository.GetByLogin")
+	defer func() { tracing.End(span, err) }()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	userModel, err := r.storage.GetByLogin(login)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(userModel)
+}
+
+func (r *UserRepositoryImpl) AddRatings(ctx context.Context, changes map[uuid.UUID]int) (err error) {
+	defer r.metrics.ObserveRepository("users", "add_ratings", time.Now())
+	ctx, span := tracer.Start(ctx, "UserRepository.AddRatings")
+	defer func() { tracing.End(span, err) }()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	byID := make(map[string]int, len(changes))
+	for userID, change := range changes {
+		byID[userID.String()] = change
+	}
+
+	if err := r.storage.AddRatings(byID); err != nil {
+		logging.FromContext(ctx, r.logger).Error("failed to update ratings", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryImpl) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.storage.Ping()
+}
+
+func (r *UserRepositoryImpl) toDomain(userModel *dsModel.UserModel) (*model.User, error) {
+	user, err := mapper.FromUserDsToDomain(userModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert model to user: %w", err)
+	}
+
+	return user, nil
+}
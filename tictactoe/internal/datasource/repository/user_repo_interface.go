@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/model"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user *model.User) error
+
+	Get(ctx context.Context, userID uuid.UUID) (*model.User, error)
+
+	GetByLogin(ctx context.Context, login string) (*model.User, error)
+
+	AddRatings(ctx context.Context, changes map[uuid.UUID]int) error
+
+	Ping(ctx context.Context) error
+}
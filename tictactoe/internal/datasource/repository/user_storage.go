@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"sync"
+
+	"tictactoe/internal/datasource/model"
+	domainModel "tictactoe/internal/domain/model"
+)
+
+type UserStorage struct {
+	mu      sync.RWMutex
+	byID    map[string]*model.UserModel
+	byLogin map[string]*model.UserModel
+	file    *fileSnapshot
+}
+
+func NewUserStorage() *UserStorage {
+	return &UserStorage{
+		byID:    make(map[string]*model.UserModel),
+		byLogin: make(map[string]*model.UserModel),
+	}
+}
+
+func NewFileUserStorage(path string) (*UserStorage, error) {
+	file, err := newFileSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*model.UserModel
+	if err := file.load(&users); err != nil {
+		return nil, err
+	}
+
+	storage := NewUserStorage()
+	storage.file = file
+	for _, user := range users {
+		storage.byID[user.ID] = user
+		storage.byLogin[user.Login] = user
+	}
+
+	return storage, nil
+}
+
+func (storage *UserStorage) Create(user *model.UserModel) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	if _, exists := storage.byLogin[user.Login]; exists {
+		return domainModel.ErrLoginTaken
+	}
+
+	storage.byID[user.ID] = user
+	storage.byLogin[user.Login] = user
+
+	if err := storage.persist(); err != nil {
+		delete(storage.byID, user.ID)
+		delete(storage.byLogin, user.Login)
+		return err
+	}
+
+	return nil
+}
+
+// AddRatings changes the ratings of several users at once: either all of them
+// are saved or none.
+func (storage *UserStorage) AddRatings(changes map[string]int) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	previous := make(map[string]*model.UserModel, len(changes))
+	restore := func() {
+		for id, user := range previous {
+			storage.byID[id] = user
+			storage.byLogin[user.Login] = user
+		}
+	}
+
+	for id, change := range changes {
+		user, exists := storage.byID[id]
+		if !exists {
+			restore()
+			return domainModel.ErrUserNotFound
+		}
+
+		updated := *user
+		updated.Rating += change
+		previous[id] = user
+		storage.byID[id] = &updated
+		storage.byLogin[user.Login] = &updated
+	}
+
+	if err := storage.persist(); err != nil {
+		restore()
+		return err
+	}
+
+	return nil
+}
+
+func (storage *UserStorage) Ping() error {
+	if storage.file != nil {
+		return storage.file.probe()
+	}
+
+	return nil
+}
+
+func (storage *UserStorage) Flush() error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	return storage.persist()
+}
+
+func (storage *UserStorage) persist() error {
+	if storage.file == nil {
+		return nil
+	}
+
+	users := make([]*model.UserModel, 0, len(storage.byID))
+	for _, user := range storage.byID {
+		users = append(users, user)
+	}
+
+	return storage.file.save(users)
+}
+
+func (storage *UserStorage) Get(userID string) (*model.UserModel, error) {
+	storage.mu.RLock()
+	defer storage.mu.RUnlock()
+
+	user, exists := storage.byID[userID]
+	if !exists {
+		return nil, domainModel.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (storage *UserStorage) GetByLogin(login string) (*model.UserModel, error) {
+	storage.mu.RLock()
+	defer storage.mu.RUnlock()
+
+	user, exists := storage.byLogin[login]
+	if !exists {
+		return nil, domainModel.ErrUserNotFound
+	}
+
+	return user, nil
+}
@@ -4,17 +4,52 @@ import "go.uber.org/fx"
 
 var Module = fx.Module("tictactoe",
 	fx.Provide(
+		NewConfig,
+		NewConfigSections,
+		NewLogger,
+		NewMetrics,
+		
 		NewGameStorage,
 		NewGameRepository,
+		NewUserStorage,
+		NewUserRepository,
 		
+		NewHub,
+		NewEventBus,
 		NewMinimax,
+		NewSearchExecutor,
 		NewGameService,
+		NewUserService,
+		NewMatchmakingService,
 
+		NewRateLimits,
+		NewJwtProvider,
 		NewGameHandler,
+		NewUserHandler,
+		NewMatchmakingHandler,
+		NewGameSocketHandler,
+		NewEventsHandler,
+		NewReadinessChecker,
+		NewHealthHandler,
+		NewUIHandler,
 		NewRouter,
+		
+		NewGameServer,
+		NewGRPCServer,
+	),
+	
+	fx.Provide(
+		fx.Annotate(NewAuditSubscriber, fx.ResultTags(`group:"event_subscribers"`)),
+		fx.Annotate(NewHubSubscriber, fx.ResultTags(`group:"event_subscribers"`)),
+		fx.Annotate(NewRatingSubscriber, fx.ResultTags(`group:"event_subscribers"`)),
+		fx.Annotate(NewWebhookDispatcher, fx.ResultTags(`group:"event_subscribers"`)),
 	),
 	
 	fx.Invoke(
+		RegisterTracing,
+		fx.Annotate(RegisterEventSubscribers, fx.ParamTags(``, ``, `group:"event_subscribers"`, ``)),
+		RegisterAdminServer,
 		RegisterServer,
+		RegisterShutdown,
 	),
 )
\ No newline at end of file
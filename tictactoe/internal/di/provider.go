@@ -2,113 +2,608 @@ package di
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"path/filepath"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
 
+	"tictactoe/internal/algorithm/executor"
 	"tictactoe/internal/algorithm/minimax"
+	"tictactoe/internal/config"
 	"tictactoe/internal/datasource/repository"
+	"tictactoe/internal/domain/event"
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/notify"
 	"tictactoe/internal/domain/service"
+	"tictactoe/internal/health"
+	"tictactoe/internal/logging"
+	"tictactoe/internal/metrics"
+	"tictactoe/internal/ratelimit"
+	"tictactoe/internal/rpc"
+	"tictactoe/internal/rpc/gamepb"
+	"tictactoe/internal/tracing"
+	"tictactoe/internal/web/auth"
 	"tictactoe/internal/web/module"
 	"tictactoe/internal/web/route"
+	"tictactoe/internal/web/ui"
+	"tictactoe/internal/webhook"
 )
 
-func NewGameStorage() *repository.GameStorage {
-	log.Println("[DI] Creating GameStorage (singleton)")
-	return repository.NewGameStorage()
+const readinessTimeout = 2 * time.Second
+
+type ConfigSections struct {
+	fx.Out
+
+	Log         config.LogConfig
+	Tracing     config.TracingConfig
+	HTTP        config.HTTPConfig
+	GRPC        config.GRPCConfig
+	Admin       config.AdminConfig
+	Engine      config.EngineConfig
+	Storage     config.StorageConfig
+	CORS        config.CORSConfig
+	RateLimit   config.RateLimitConfig
+	Idempotency config.IdempotencyConfig
+	Auth        config.AuthConfig
+	Matchmaking config.MatchmakingConfig
+	Webhooks    config.WebhookConfig
 }
 
-func NewGameRepository(storage *repository.GameStorage) repository.GameRepository {
-	log.Println("[DI] Creating GameRepository")
-	return repository.NewGameRepo(storage)
+func NewConfig() (*config.Config, error) {
+	return config.Load(os.Args[1:])
 }
 
-func NewGameService(repo repository.GameRepository, algo service.MinimaxAlgorithm) service.GameService {
-	log.Println("[DI] Creating GameService")
-	return service.NewGameService(repo, algo)
+func NewLogger(lc fx.Lifecycle, cfg *config.Config) (*zap.Logger, error) {
+	logger, err := logging.New(cfg.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.File != "" {
+		logger.Info("loaded config file", zap.String("path", cfg.File))
+	}
+	if cfg.Auth.RandomKey {
+		logger.Warn("signing key is not configured, using a random one: tokens will not survive a restart")
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Sync()
+			return nil
+		},
+	})
+
+	return logger, nil
 }
 
-func NewMinimax() service.MinimaxAlgorithm {
-	log.Println("[DI] Creating MiniMax")
-	return minimax.NewMinimax(2)
+func NewFxLogger(logger *zap.Logger) fxevent.Logger {
+	fxLogger := &fxevent.ZapLogger{Logger: logger.Named("fx")}
+	fxLogger.UseLogLevel(zapcore.DebugLevel)
+	return fxLogger
 }
 
-func NewGameHandler(service service.GameService) *module.GameHandler {
-	log.Println("[DI] Creating GameHandler")
-	return module.NewGameHandler(service)
+func NewConfigSections(cfg *config.Config) ConfigSections {
+	return ConfigSections{
+		Log:         cfg.Log,
+		Tracing:     cfg.Tracing,
+		HTTP:        cfg.HTTP,
+		GRPC:        cfg.GRPC,
+		Admin:       cfg.Admin,
+		Engine:      cfg.Engine,
+		Storage:     cfg.Storage,
+		CORS:        cfg.CORS,
+		RateLimit:   cfg.RateLimit,
+		Idempotency: cfg.Idempotency,
+		Auth:        cfg.Auth,
+		Matchmaking: cfg.Matchmaking,
+		Webhooks:    cfg.Webhooks,
+	}
 }
 
-func NewRouter(handler *module.GameHandler) http.Handler {
-	log.Println("[DI] Creating Router")
-	return route.NewRouter(handler)
+func RegisterTracing(lc fx.Lifecycle, cfg config.TracingConfig, logger *zap.Logger) error {
+	if cfg.Exporter == config.TracingNone {
+		return nil
+	}
+
+	logger = logger.Named("tracing")
+
+	provider, err := tracing.New(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn("tracing error", zap.Error(err))
+	}))
+
+	logger.Info("tracing enabled",
+		zap.String("exporter", cfg.Exporter),
+		zap.Float64("sample_ratio", cfg.SampleRatio))
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Info("flushing traces")
+			return provider.Shutdown(ctx)
+		},
+	})
+
+	return nil
+}
+
+func NewGameStorage(lc fx.Lifecycle, cfg config.StorageConfig, logger *zap.Logger) (*repository.GameStorage, error) {
+	if cfg.Backend == config.StorageFile {
+		path := filepath.Join(cfg.Dir, "games.json")
+		storage, err := repository.NewFileGameStorage(path, cfg.MaxGames)
+		if err != nil {
+			return nil, err
+		}
+
+		lc.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				logger.Named("repository").Info("flushing game storage", zap.String("path", path))
+				return storage.Flush()
+			},
+		})
+
+		return storage, nil
+	}
+
+	return repository.NewGameStorage(cfg.MaxGames), nil
 }
 
-func RegisterServer(lc fx.Lifecycle, router http.Handler) {
+func NewGameRepository(storage *repository.GameStorage, logger *zap.Logger, m *metrics.Metrics) repository.GameRepository {
+	m.RegisterActiveGames(storage.CountInProgress)
+	return repository.NewGameRepo(storage, logger.Named("repository"), m)
+}
+
+func NewUserStorage(lc fx.Lifecycle, cfg config.StorageConfig, logger *zap.Logger) (*repository.UserStorage, error) {
+	if cfg.Backend == config.StorageFile {
+		path := filepath.Join(cfg.Dir, "users.json")
+		storage, err := repository.NewFileUserStorage(path)
+		if err != nil {
+			return nil, err
+		}
+
+		lc.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				logger.Named("repository").Info("flushing user storage", zap.String("path", path))
+				return storage.Flush()
+			},
+		})
+
+		return storage, nil
+	}
+
+	return repository.NewUserStorage(), nil
+}
+
+func NewUserRepository(storage *repository.UserStorage, logger *zap.Logger, m *metrics.Metrics) repository.UserRepository {
+	return repository.NewUserRepo(storage, logger.Named("repository"), m)
+}
+
+func NewMetrics() *metrics.Metrics {
+	return metrics.New()
+}
+
+func NewHub() *notify.Hub {
+	return notify.NewHub()
+}
+
+func NewEventBus(logger *zap.Logger, m *metrics.Metrics) *event.Bus {
+	return event.NewBus(event.DefaultBufferSize, event.DefaultWorkers, logger.Named("events"), m)
+}
+
+func NewAuditSubscriber(logger *zap.Logger) event.Subscriber {
+	return event.NewAuditSubscriber(logger.Named("audit"))
+}
+
+func NewHubSubscriber(hub *notify.Hub) event.Subscriber {
+	return notify.NewHubSubscriber(hub)
+}
+
+func NewRatingSubscriber(repo repository.UserRepository, logger *zap.Logger) event.Subscriber {
+	return service.NewRatingSubscriber(repo, logger.Named("ratings"))
+}
+
+func NewWebhookDispatcher(lc fx.Lifecycle, cfg config.WebhookConfig, logger *zap.Logger) (event.Subscriber, error) {
+	logger = logger.Named("webhooks")
+
+	global := make([]model.Webhook, 0, len(cfg.URLs))
+	for _, url := range cfg.URLs {
+		global = append(global, model.Webhook{
+			URL:    url,
+			Events: cfg.Events,
+			Secret: cfg.Secret,
+		})
+	}
+	for _, name := range cfg.Events {
+		if !event.IsKnownType(name) {
+			return nil, fmt.Errorf("invalid webhook event %q", name)
+		}
+	}
+	
+	deadLetter, err := webhook.NewDeadLetterLog(cfg.DeadLetterLog, logger)
+	if err != nil {
+		return nil, err
+	}
+	
+	dispatcher := webhook.NewDispatcher(webhook.Options{
+		Global:         global,
+		MaxAttempts:    cfg.MaxAttempts,
+		InitialBackoff: cfg.InitialBackoff,
+		MaxBackoff:     cfg.MaxBackoff,
+		Timeout:        cfg.Timeout,
+		Workers:        cfg.Workers,
+		DeadLetter:     deadLetter,
+	}, logger)
+	
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Info("flushing webhook deliveries")
+			err := dispatcher.Close(ctx)
+			deadLetter.Close()
+			return err
+		},
+	})
+	
+	return dispatcher, nil
+}
+
+func RegisterEventSubscribers(lc fx.Lifecycle, bus *event.Bus, subscribers []event.Subscriber, logger *zap.Logger) {
+	for _, subscriber := range subscribers {
+		logger.Named("events").Debug("subscribing to event bus", zap.String("subscriber", subscriber.Name()))
+		bus.Subscribe(subscriber)
+	}
+	
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Named("events").Info("draining event bus")
+			return bus.Close(ctx)
+		},
+	})
+}
+
+func NewGameService(repo repository.GameRepository, algo service.MinimaxAlgorithm, executor service.SearchExecutor, bus *event.Bus,
+	logger *zap.Logger, m *metrics.Metrics) service.GameService {
+	return service.NewGameService(repo, algo, executor, bus, logger.Named("service"), m)
+}
+
+func NewUserService(repo repository.UserRepository) service.UserService {
+	return service.NewUserService(repo)
+}
+
+func NewMatchmakingService(cfg config.MatchmakingConfig, gameService service.GameService, userService service.UserService, hub *notify.Hub) service.MatchmakingService {
+	return service.NewMatchmakingService(gameService, userService, hub, cfg.WaitTimeout, cfg.RatingWindow)
+}
+
+func NewMinimax(cfg config.EngineConfig, logger *zap.Logger, m *metrics.Metrics) service.MinimaxAlgorithm {
+	return minimax.NewMinimax(2, minimax.Options{
+		MaxSearchNodes: cfg.MaxSearchNodes,
+		MaxDepth:       cfg.MaxDepth,
+	}, logger.Named("engine"), m)
+}
+
+func NewSearchExecutor(lc fx.Lifecycle, cfg config.EngineConfig, m *metrics.Metrics, logger *zap.Logger) service.SearchExecutor {
+	pool := executor.New(executor.Options{
+		Workers:   cfg.Workers,
+		QueueSize: cfg.QueueSize,
+		Timeout:   cfg.SearchTimeout,
+	}, m)
+	
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Named("engine").Info("stopping search workers")
+			pool.Close()
+			return nil
+		},
+	})
+	
+	return pool
+}
+
+func NewGameHandler(service service.GameService, logger *zap.Logger) *module.GameHandler {
+	return module.NewGameHandler(service, logger.Named("http"))
+}
+
+func NewRateLimits(cfg config.RateLimitConfig, m *metrics.Metrics) ratelimit.Guard {
+	return ratelimit.NewLimits(ratelimit.Options{
+		Key:            cfg.Key,
+		APIKeys:        cfg.APIKeys,
+		TrustedProxies: cfg.TrustedProxies,
+		MaxClients:     cfg.MaxClients,
+		Create:         ratelimit.Limit{PerMinute: cfg.CreatePerMinute, Burst: cfg.CreateBurst},
+		Move:           ratelimit.Limit{PerMinute: cfg.MovePerMinute, Burst: cfg.MoveBurst},
+		Render:         ratelimit.Limit{PerMinute: cfg.RenderPerMinute, Burst: cfg.RenderBurst},
+	}, m)
+}
+
+func NewJwtProvider(cfg config.AuthConfig) *auth.JwtProvider {
+	return auth.NewJwtProvider(cfg.SigningKey, cfg.TokenTTL)
+}
+
+func NewUserHandler(service service.UserService, jwt *auth.JwtProvider) *module.UserHandler {
+	return module.NewUserHandler(service, jwt)
+}
+
+func NewMatchmakingHandler(service service.MatchmakingService) *module.MatchmakingHandler {
+	return module.NewMatchmakingHandler(service)
+}
+
+func NewGameSocketHandler(service service.GameService, hub *notify.Hub, limits ratelimit.Guard, corsCfg config.CORSConfig) *module.GameSocketHandler {
+	return module.NewGameSocketHandler(service, hub, limits, route.OriginMatcher(corsCfg.AllowedOrigins))
+}
+
+func NewEventsHandler(service service.GameService, hub *notify.Hub, jwt *auth.JwtProvider) *module.EventsHandler {
+	return module.NewEventsHandler(service, hub, jwt)
+}
+
+func NewReadinessChecker(gameRepo repository.GameRepository, userRepo repository.UserRepository, gameService service.GameService) *health.Checker {
+	return health.NewChecker(readinessTimeout,
+		health.Check{Name: "game_repository", Run: gameRepo.Ping, Details: storageCapacity(gameRepo)},
+		health.Check{Name: "user_repository", Run: userRepo.Ping},
+		health.Check{Name: "engine", Run: gameService.CheckEngine},
+		health.Check{Name: "shutdown", Run: gameService.CheckShutdown},
+	)
+}
+
+// storageCapacity shows how full the game storage is. A full storage keeps
+// the server ready: existing games are still served, only new ones fail.
+func storageCapacity(gameRepo repository.GameRepository) func() map[string]any {
+	return func() map[string]any {
+		games, maxGames := gameRepo.Capacity()
+		details := map[string]any{"games": games}
+		if maxGames > 0 {
+			details["max_games"] = maxGames
+			details["full"] = games >= maxGames
+		}
+		return details
+	}
+}
+
+func NewHealthHandler(readiness *health.Checker) *module.HealthHandler {
+	return module.NewHealthHandler(readiness)
+}
+
+func NewUIHandler() (*ui.Handler, error) {
+	return ui.NewHandler()
+}
+
+func NewRouter(httpCfg config.HTTPConfig, corsCfg config.CORSConfig, limits ratelimit.Guard,
+	idempotencyCfg config.IdempotencyConfig, handler *module.GameHandler, userHandler *module.UserHandler,
+	matchmakingHandler *module.MatchmakingHandler, socketHandler *module.GameSocketHandler, eventsHandler *module.EventsHandler,
+	healthHandler *module.HealthHandler, uiHandler *ui.Handler, jwt *auth.JwtProvider, logger *zap.Logger, m *metrics.Metrics) http.Handler {
+	return route.NewRouter(handler, userHandler, matchmakingHandler, socketHandler, eventsHandler, healthHandler, uiHandler, jwt, logger.Named("http"), m, route.Options{
+		CORS: route.CORSOptions{
+			AllowedOrigins:   corsCfg.AllowedOrigins,
+			AllowedMethods:   corsCfg.AllowedMethods,
+			AllowedHeaders:   corsCfg.AllowedHeaders,
+			ExposedHeaders:   corsCfg.ExposedHeaders,
+			AllowCredentials: corsCfg.AllowCredentials,
+			MaxAge:           corsCfg.MaxAge,
+		},
+		MaxBodyBytes: httpCfg.MaxBodyBytes,
+		RateLimit:    limits,
+		Idempotency: route.IdempotencyOptions{
+			TTL:     idempotencyCfg.TTL,
+			MaxKeys: idempotencyCfg.MaxKeys,
+		},
+	})
+}
+
+func NewGameServer(service service.GameService, hub *notify.Hub, limits ratelimit.Guard) *rpc.GameServer {
+	return rpc.NewGameServer(service, hub, limits)
+}
+
+func NewGRPCServer(gameServer *rpc.GameServer, jwt *auth.JwtProvider, logger *zap.Logger, m *metrics.Metrics) *grpc.Server {
+	logger = logger.Named("grpc")
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			rpc.UnaryLoggingInterceptor(logger),
+			rpc.UnaryMetricsInterceptor(m),
+			rpc.UnaryRecoveryInterceptor(logger),
+			rpc.UnaryAuthInterceptor(jwt),
+		),
+		grpc.ChainStreamInterceptor(
+			rpc.StreamLoggingInterceptor(logger),
+			rpc.StreamMetricsInterceptor(m),
+			rpc.StreamRecoveryInterceptor(logger),
+			rpc.StreamAuthInterceptor(jwt),
+		),
+	)
+	gamepb.RegisterGameServiceServer(server, gameServer)
+	return server
+}
+
+var endpoints = []string{
+	"POST /auth/register - Register new user",
+	"POST /auth/login - Log in and get a JWT",
+	"GET /user/me - Current user",
+	"GET /user/stats - Current user statistics",
+	"GET /games - List my games",
+	"POST /game - Create new game",
+	"POST /matchmaking - Find a PvP opponent",
+	"GET /game/{id} - Get game info",
+	"POST /game/{id} - Make a move (Prefer: respond-async for a background AI reply)",
+	"GET /game/{id}/jobs/{job} - AI move job status",
+	"GET /game/{id}/image.svg|.png - Board image",
+	"GET /game/{id}/replay.gif - Animated replay",
+	"POST /game/{id}/abandon - Abandon (resign) a game",
+	"GET /game/{id}/ws - Live game updates (WebSocket)",
+	"GET /game/{id}/events - Live game updates (Server-Sent Events)",
+	"POST /game/{id}/stream-token - Short-lived token for the game's WebSocket and SSE URLs",
+	"GET /lobby/events - Lobby updates (Server-Sent Events)",
+	"POST /lobby/stream-token - Short-lived token for the lobby SSE URL",
+	"GET /health - Health check",
+	"GET /livez - Liveness probe",
+	"GET /readyz - Readiness probe with dependency checks",
+	"GET / - Browser UI",
+}
+
+// RegisterServer binds the HTTP and gRPC ports in OnStart, so a busy port fails
+// the start instead of killing the process later. A server that stops with an
+// error shuts the application down gracefully.
+func RegisterServer(lc fx.Lifecycle, shutdowner fx.Shutdowner, httpCfg config.HTTPConfig, grpcCfg config.GRPCConfig, router http.Handler,
+	grpcServer *grpc.Server, logger *zap.Logger) {
+	logger = logger.Named("server")
+
 	server := &http.Server{
-		Addr:         ":8080",
+		Addr:         fmt.Sprintf(":%d", httpCfg.Port),
 		Handler:      router,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  30 * time.Second,
+		ErrorLog:     zap.NewStdLog(logger.Named("http")),
+		ReadTimeout:  httpCfg.ReadTimeout,
+		WriteTimeout: httpCfg.WriteTimeout,
+		IdleTimeout:  httpCfg.IdleTimeout,
 	}
 	
-	setupGracefulShutdown(server)
+	grpcAddr := fmt.Sprintf(":%d", grpcCfg.Port)
 	
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			log.Println("[DI] Starting HTTP server on :8080")
+			httpListener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", server.Addr, err)
+			}
+			
+			grpcListener, err := net.Listen("tcp", grpcAddr)
+			if err != nil {
+				httpListener.Close()
+				return fmt.Errorf("failed to listen on %s: %w", grpcAddr, err)
+			}
+			
+			logger.Info("starting HTTP server", zap.String("addr", server.Addr))
+			logger.Info("starting gRPC server", zap.String("addr", grpcAddr))
 			
 			go func() {
-				if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-					log.Fatalf("[DI] HTTP server error: %v", err)
+				if err := server.Serve(httpListener); err != nil && err != http.ErrServerClosed {
+					logger.Error("HTTP server error", zap.Error(err))
+					shutdowner.Shutdown(fx.ExitCode(1))
+				}
+			}()
+			
+			go func() {
+				if err := grpcServer.Serve(grpcListener); err != nil && err != grpc.ErrServerStopped {
+					logger.Error("gRPC server error", zap.Error(err))
+					shutdowner.Shutdown(fx.ExitCode(1))
 				}
 			}()
 			
 			go func() {
 				time.Sleep(100 * time.Millisecond)
-				log.Println("[DI] Server is ready")
-				log.Println("[DI] Available endpoints:")
-				log.Println("[DI]   POST   /game          - Create new game")
-				log.Println("[DI]   GET    /game/{id}     - Get game info")
-				log.Println("[DI]   POST   /game/{id}     - Make a move")
-				log.Println("[DI]   GET    /health        - Health check")
+				logger.Info("server is ready",
+					zap.Strings("endpoints", endpoints),
+					zap.String("api_prefix", route.APIVersionPrefix),
+					zap.String("grpc_service", "tictactoe.v1.GameService"))
 			}()
 			
 			return nil
 		},
 		
 		OnStop: func(ctx context.Context) error {
-			log.Println("[DI] Shutting down HTTP server...")
+			logger.Info("shutting down HTTP server")
 			
-			shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			shutdownCtx, cancel := context.WithTimeout(ctx, httpCfg.ShutdownTimeout)
 			defer cancel()
 			
+			logger.Info("shutting down gRPC server")
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			
+			err := server.Shutdown(shutdownCtx)
+			
+			select {
+			case <-stopped:
+			case <-shutdownCtx.Done():
+				grpcServer.Stop()
+			}
+			
+			return err
+		},
+	})
+}
+
+func RegisterAdminServer(lc fx.Lifecycle, adminCfg config.AdminConfig, httpCfg config.HTTPConfig, m *metrics.Metrics, logger *zap.Logger) {
+	if adminCfg.Port == 0 {
+		return
+	}
+
+	logger = logger.Named("admin")
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", m.Handler())
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", adminCfg.Port),
+		Handler:           mux,
+		ErrorLog:          zap.NewStdLog(logger),
+		ReadHeaderTimeout: httpCfg.ReadTimeout,
+		IdleTimeout:       httpCfg.IdleTimeout,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", server.Addr, err)
+			}
+
+			logger.Info("starting admin server", zap.String("addr", server.Addr))
+
+			go func() {
+				if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+					logger.Error("admin server error", zap.Error(err))
+				}
+			}()
+
+			return nil
+		},
+
+		OnStop: func(ctx context.Context) error {
+			logger.Info("shutting down admin server")
+
+			shutdownCtx, cancel := context.WithTimeout(ctx, httpCfg.ShutdownTimeout)
+			defer cancel()
+
 			return server.Shutdown(shutdownCtx)
 		},
 	})
 }
 
-func setupGracefulShutdown(server *http.Server) {
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
-	
-	go func() {
-		<-stop
-		log.Println("\n[DI] Received shutdown signal")
-		
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		
-		if err := server.Shutdown(ctx); err != nil {
-			log.Printf("[DI] Server shutdown error: %v", err)
-		}
-		
-		log.Println("[DI] Server stopped")
-		os.Exit(0)
-	}()
-}
\ No newline at end of file
+func RegisterShutdown(lc fx.Lifecycle, httpCfg config.HTTPConfig, gameService service.GameService,
+	matchmaking service.MatchmakingService, hub *notify.Hub, logger *zap.Logger) {
+	logger = logger.Named("shutdown")
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Info("closing matchmaking queue")
+			if err := matchmaking.Shutdown(ctx); err != nil {
+				logger.Error("matchmaking shutdown error", zap.Error(err))
+			}
+
+			logger.Info("draining engine searches", zap.Duration("timeout", httpCfg.ShutdownTimeout))
+			searchCtx, cancel := context.WithTimeout(ctx, httpCfg.ShutdownTimeout)
+			defer cancel()
+
+			err := gameService.Shutdown(searchCtx)
+
+			logger.Info("closing live update streams")
+			hub.Close()
+
+			return err
+		},
+	})
+}
@@ -0,0 +1,46 @@
+package event
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type AuditSubscriber struct {
+	logger *zap.Logger
+}
+
+func NewAuditSubscriber(logger *zap.Logger) *AuditSubscriber {
+	return &AuditSubscriber{logger: logger}
+}
+
+func (s *AuditSubscriber) Name() string {
+	return "audit"
+}
+
+func (s *AuditSubscriber) Types() []Type {
+	return []Type{GameCreated, MoveMade, MoveReverted, GameFinished, GameAbandoned, DrawOffered, DrawDeclined}
+}
+
+func (s *AuditSubscriber) Handle(ctx context.Context, event Event) {
+	fields := eventFields(event)
+
+	switch {
+	case event.Move != nil:
+		fields = append(fields,
+			zap.Int("player", event.Move.Player),
+			zap.Int("row", event.Move.Row),
+			zap.Int("col", event.Move.Col))
+	case event.Game != nil:
+		fields = append(fields,
+			zap.String("mode", event.Game.Mode),
+			zap.Int("size", event.Game.Size),
+			zap.String("state", event.Game.State))
+	}
+
+	if event.Type == MoveMade {
+		s.logger.Debug("game event", fields...)
+		return
+	}
+	s.logger.Info("game event", fields...)
+}
@@ -0,0 +1,225 @@
+package event
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"tictactoe/internal/metrics"
+)
+
+const (
+	DefaultBufferSize = 256
+	DefaultWorkers    = 4
+
+	// reliableWait bounds how long Publish waits for a Reliable subscriber
+	// with a full buffer, so a stuck subscriber cannot stall requests.
+	reliableWait = 2 * time.Second
+)
+
+// Reliable is implemented by subscribers that must see every event, such as
+// ratings and webhooks.
+type Reliable interface {
+	Reliable() bool
+}
+
+type delivery struct {
+	ctx   context.Context
+	event Event
+}
+
+type subscription struct {
+	subscriber Subscriber
+	types      map[Type]bool
+	reliable   bool
+	shards     []chan delivery
+}
+
+// Bus delivers events to subscribers asynchronously. When a subscriber's
+// buffer is full, Publish drops the event for it, except for a Reliable
+// subscriber, for which it waits for room first. Every dropped event is
+// logged and counted.
+type Bus struct {
+	bufferSize int
+	workers    int
+	logger     *zap.Logger
+	metrics    *metrics.Metrics
+
+	mu            sync.RWMutex
+	subscriptions []*subscription
+	closed        bool
+	wg            sync.WaitGroup
+}
+
+func NewBus(bufferSize, workers int, logger *zap.Logger, m *metrics.Metrics) *Bus {
+	return &Bus{
+		bufferSize: bufferSize,
+		workers:    workers,
+		logger:     logger,
+		metrics:    m,
+	}
+}
+
+func (b *Bus) Subscribe(subscriber Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	sub := &subscription{
+		subscriber: subscriber,
+		shards:     make([]chan delivery, b.workers),
+	}
+	if reliable, ok := subscriber.(Reliable); ok {
+		sub.reliable = reliable.Reliable()
+	}
+
+	if types := subscriber.Types(); len(types) > 0 {
+		sub.types = make(map[Type]bool, len(types))
+		for _, eventType := range types {
+			sub.types[eventType] = true
+		}
+	}
+
+	for i := range sub.shards {
+		sub.shards[i] = make(chan delivery, b.bufferSize)
+		b.wg.Add(1)
+		go b.dispatch(sub, sub.shards[i])
+	}
+
+	b.subscriptions = append(b.subscriptions, sub)
+}
+
+// Publish hands the event to the subscribers together with ctx, detached from
+// its cancellation, so their work continues the publisher's trace.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	shard := b.shard(event.GameID)
+	item := delivery{ctx: context.WithoutCancel(ctx), event: event}
+
+	for _, sub := range b.subscriptions {
+		if sub.types != nil && !sub.types[event.Type] {
+			continue
+		}
+
+		switch {
+		case b.closed:
+			b.drop(sub, event, "bus is closed")
+		case b.send(sub, sub.shards[shard], item):
+		default:
+			b.drop(sub, event, "subscriber is full")
+		}
+	}
+}
+
+func (b *Bus) send(sub *subscription, shard chan<- delivery, item delivery) bool {
+	select {
+	case shard <- item:
+		return true
+	default:
+	}
+	if !sub.reliable {
+		return false
+	}
+
+	timer := time.NewTimer(reliableWait)
+	defer timer.Stop()
+
+	select {
+	case shard <- item:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (b *Bus) drop(sub *subscription, event Event, reason string) {
+	b.metrics.EventDropped(sub.subscriber.Name(), string(event.Type))
+
+	fields := append(eventFields(event), zap.String("subscriber", sub.subscriber.Name()))
+	if sub.reliable {
+		b.logger.Error(reason+", dropping event", fields...)
+	} else {
+		b.logger.Warn(reason+", dropping event", fields...)
+	}
+}
+
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, sub := range b.subscriptions {
+			for _, shard := range sub.shards {
+				close(shard)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *Bus) dispatch(sub *subscription, deliveries <-chan delivery) {
+	defer b.wg.Done()
+
+	for item := range deliveries {
+		b.handle(item.ctx, sub.subscriber, item.event)
+	}
+}
+
+func (b *Bus) handle(ctx context.Context, subscriber Subscriber, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("subscriber panicked",
+				append(eventFields(event), zap.String("subscriber", subscriber.Name()), zap.Any("panic", r))...)
+		}
+	}()
+
+	subscriber.Handle(ctx, event)
+}
+
+func (b *Bus) shard(gameID uuid.UUID) int {
+	hash := fnv.New32a()
+	hash.Write(gameID[:])
+	return int(hash.Sum32() % uint32(b.workers))
+}
+
+func eventFields(event Event) []zap.Field {
+	fields := []zap.Field{
+		zap.String("event", string(event.Type)),
+		zap.Stringer("event_id", event.ID),
+		zap.Stringer("game_id", event.GameID),
+	}
+	if event.UserID != uuid.Nil {
+		fields = append(fields, zap.Stringer("user_id", event.UserID))
+	}
+	if event.RequestID != "" {
+		fields = append(fields, zap.String("request_id", event.RequestID))
+	}
+	return fields
+}
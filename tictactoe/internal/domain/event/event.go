@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/model"
+)
+
+type Type string
+
+const (
+	GameCreated   Type = "GameCreated"
+	MoveMade      Type = "MoveMade"
+	MoveReverted  Type = "MoveReverted"
+	GameFinished  Type = "GameFinished"
+	GameAbandoned Type = "GameAbandoned"
+	ChatMessage   Type = "ChatMessage"
+	DrawOffered   Type = "DrawOffered"
+	DrawDeclined  Type = "DrawDeclined"
+)
+
+var knownTypes = map[Type]bool{
+	GameCreated:   true,
+	MoveMade:      true,
+	MoveReverted:  true,
+	GameFinished:  true,
+	GameAbandoned: true,
+	ChatMessage:   true,
+	DrawOffered:   true,
+	DrawDeclined:  true,
+}
+
+func IsKnownType(name string) bool {
+	return knownTypes[Type(name)]
+}
+
+type Event struct {
+	ID         uuid.UUID
+	Type       Type
+	GameID     uuid.UUID
+	Game       *model.Game
+	Move       *model.Move
+	UserID     uuid.UUID
+	Message    string
+	RequestID  string
+	OccurredAt time.Time
+}
+
+type Subscriber interface {
+	Name() string
+	Types() []Type
+	Handle(ctx context.Context, event Event)
+}
@@ -0,0 +1,13 @@
+package model
+
+import "errors"
+
+var (
+	ErrGameNotFound = errors.New("game not found")
+	ErrUserNotFound = errors.New("user not found")
+	ErrLoginTaken   = errors.New("login is already taken")
+	ErrCellOccupied = errors.New("cell already occupied")
+	ErrInvalidMove  = errors.New("invalid move")
+	ErrConflict     = errors.New("conflict with the current game state")
+	ErrStorageFull  = errors.New("game storage is full")
+)
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	JobPending = "pending"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// AIJob tracks an AI move computed in the background after the player's move
+// has been saved.
+type AIJob struct {
+	ID         uuid.UUID
+	GameID     uuid.UUID
+	Status     string
+	Move       *Move
+	Error      string
+	CreatedAt  time.Time
+	FinishedAt time.Time
+}
+
+func (j *AIJob) IsFinished() bool {
+	return j.Status != JobPending
+}
@@ -8,6 +8,21 @@ import (
 
 const FieldSize int = 3
 
+const (
+	MinFieldSize int = 3
+	MaxFieldSize int = 10
+)
+
+const (
+	PlayerX = 1
+	PlayerO = 2
+)
+
+const (
+	ModeAI  = "ai"
+	ModePvP = "pvp"
+)
+
 type GameField [][]int
 
 const (
@@ -15,12 +30,42 @@ const (
 	StatePlayerWon = "Player won"
 	StateAIWon = "AI won"
 	StateDraw = "Draw"
+	StateFirstPlayerWon = "First player won"
+	StateSecondPlayerWon = "Second player won"
+	StateAIThinking = "AI thinking"
 )
 
+type Move struct {
+	Row    int
+	Col    int
+	Player int
+}
+
+type Webhook struct {
+	URL    string
+	Events []string
+	Secret string
+}
+
+type GameOptions struct {
+	Size       int
+	OwnerID    uuid.UUID
+	OpponentID uuid.UUID
+	Webhooks   []Webhook
+}
+
 type Game struct {
 	ID        uuid.UUID
+	OwnerID   uuid.UUID
+	OpponentID uuid.UUID
+	Mode      string
+	DrawOfferedBy uuid.UUID
+	Webhooks  []Webhook
 	Field     GameField
+	Moves     []Move
 	State     string
+	AIJobID   uuid.UUID
+	Version   int
 	PlayerTurn int
 	Size      int
 	CreatedAt time.Time
@@ -38,8 +83,16 @@ func NewField(size int) GameField {
 func (g *Game) DeepCopy() *Game {
     return &Game{
         ID:         g.ID,
+        OwnerID:    g.OwnerID,
+        OpponentID: g.OpponentID,
+        Mode:       g.Mode,
+        DrawOfferedBy: g.DrawOfferedBy,
+        Webhooks:   g.Webhooks,
         Field:      g.Field.DeepCopy(),
+        Moves:      append([]Move(nil), g.Moves...),
         State:      g.State,
+        AIJobID:    g.AIJobID,
+        Version:    g.Version,
         PlayerTurn: g.PlayerTurn,
         Size:       g.Size,
         CreatedAt:  g.CreatedAt,
@@ -59,90 +112,165 @@ func (f GameField) DeepCopy() GameField {
     return copy
 }
 
+func (g *Game) IsParticipant(userID uuid.UUID) bool {
+    return g.PlayerNumber(userID) != 0
+}
+
+func (g *Game) PlayerNumber(userID uuid.UUID) int {
+    switch {
+    case userID == uuid.Nil:
+        return 0
+    case userID == g.OwnerID:
+        return PlayerX
+    case g.Mode == ModePvP && userID == g.OpponentID:
+        return PlayerO
+    }
+    return 0
+}
+
+func (g *Game) PlayerID(player int) uuid.UUID {
+    switch player {
+    case PlayerX:
+        return g.OwnerID
+    case PlayerO:
+        return g.OpponentID
+    }
+    return uuid.Nil
+}
+
+func (g *Game) Forfeit(player int) {
+    g.State = g.winState(3 - player)
+    g.DrawOfferedBy = uuid.Nil
+    g.AIJobID = uuid.Nil
+    g.UpdatedAt = time.Now()
+}
+
+func (g *Game) IsFinished() bool {
+    return g.State != StateInProgress && g.State != StateAIThinking
+}
+
+func (g *Game) StartAIJob(jobID uuid.UUID) {
+    g.State = StateAIThinking
+    g.AIJobID = jobID
+}
+
+func (g *Game) CancelAIJob() {
+    if g.State == StateAIThinking {
+        g.State = StateInProgress
+    }
+    g.AIJobID = uuid.Nil
+}
+
+func (g *Game) UpdateState() {
+    if winner := g.CheckWinner(); winner != 0 {
+        g.State = g.winState(winner)
+    } else if g.IsFull() {
+        g.State = StateDraw
+    }
+}
+
+func (g *Game) winState(winner int) string {
+    if g.Mode == ModePvP {
+        if winner == PlayerX {
+            return StateFirstPlayerWon
+        }
+        return StateSecondPlayerWon
+    }
+    if winner == PlayerX {
+        return StatePlayerWon
+    }
+    return StateAIWon
+}
+
+func (g *Game) WinnerNumber() int {
+    switch g.State {
+    case StatePlayerWon, StateFirstPlayerWon:
+        return PlayerX
+    case StateAIWon, StateSecondPlayerWon:
+        return PlayerO
+    }
+    return 0
+}
+
 func (g *Game) MakeMove(row, col, player int) error {
     if row < 0 || row >= g.Size || col < 0 || col >= g.Size {
-        return fmt.Errorf("invalid coordinates")
+        return fmt.Errorf("%w: coordinates out of range", ErrInvalidMove)
     }
     if !g.Field.IsEmpty(row, col) {
-        return fmt.Errorf("cell already occupied")
+        return ErrCellOccupied
     }
     
+    g.CancelAIJob()
     g.Field[row][col] = player
+    g.Moves = append(g.Moves, Move{Row: row, Col: col, Player: player})
     g.PlayerTurn = 3 - player
+    g.DrawOfferedBy = uuid.Nil
     g.UpdatedAt = time.Now()
     return nil
 }
 
+// UndoMove takes back move if it is the last one and the game is still going.
+func (g *Game) UndoMove(move Move) bool {
+    if g.IsFinished() || len(g.Moves) == 0 || g.Moves[len(g.Moves)-1] != move {
+        return false
+    }
+    
+    g.CancelAIJob()
+    g.Field[move.Row][move.Col] = 0
+    g.Moves = g.Moves[:len(g.Moves)-1]
+    g.PlayerTurn = move.Player
+    g.UpdatedAt = time.Now()
+    return true
+}
+
 func (f GameField) IsEmpty(row, col int) bool {
     return f[row][col] == 0
 }
 
 func (g *Game) CheckWinner() int {
+    if line := g.WinningLine(); line != nil {
+        return line[0].Player
+    }
+    return 0
+}
+
+func (g *Game) WinningLine() []Move {
     size := g.Size
     
     for i := 0; i < size; i++ {
-        first := g.Field[i][0]
-        if first == 0 {
-            continue
-        }
-        win := true
-        for j := 1; j < size; j++ {
-            if g.Field[i][j] != first {
-                win = false
-                break
-            }
-        }
-        if win {
-            return first
+        if line := g.line(i, 0, 0, 1); line != nil {
+            return line
         }
     }
     
     for j := 0; j < size; j++ {
-        first := g.Field[0][j]
-        if first == 0 {
-            continue
-        }
-        win := true
-        for i := 1; i < size; i++ {
-            if g.Field[i][j] != first {
-                win = false
-                break
-            }
-        }
-        if win {
-            return first
+        if line := g.line(0, j, 1, 0); line != nil {
+            return line
         }
     }
     
-    first := g.Field[0][0]
-    if first != 0 {
-        win := true
-        for i := 1; i < size; i++ {
-            if g.Field[i][i] != first {
-                win = false
-                break
-            }
-        }
-        if win {
-            return first
-        }
+    if line := g.line(0, 0, 1, 1); line != nil {
+        return line
     }
     
-    first = g.Field[0][size-1]
-    if first != 0 {
-        win := true
-        for i := 1; i < size; i++ {
-            if g.Field[i][size-1-i] != first {
-                win = false
-                break
-            }
-        }
-        if win {
-            return first
-        }
+    return g.line(0, size-1, 1, -1)
+}
+
+func (g *Game) line(row, col, dRow, dCol int) []Move {
+    first := g.Field[row][col]
+    if first == 0 {
+        return nil
     }
     
-    return 0
+    line := make([]Move, 0, g.Size)
+    for k := 0; k < g.Size; k++ {
+        r, c := row+k*dRow, col+k*dCol
+        if g.Field[r][c] != first {
+            return nil
+        }
+        line = append(line, Move{Row: r, Col: c, Player: first})
+    }
+    return line
 }
 
 func (g *Game) IsFull() bool {
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type User struct {
+	ID           uuid.UUID
+	Login        string
+	PasswordHash string
+	Rating       int
+	CreatedAt    time.Time
+}
+
+const DefaultRating = 1000
+
+const ratingFactor = 32
+
+// RatingChange returns the Elo rating change of a player with the given score
+// against the opponent: 1 for a win, 0.5 for a draw, 0 for a loss. The
+// opponent's rating changes by the same amount in the other direction.
+func RatingChange(rating, opponentRating int, score float64) int {
+	expected := 1 / (1 + math.Pow(10, float64(opponentRating-rating)/400))
+	return int(math.Round(ratingFactor * (score - expected)))
+}
+
+type Match struct {
+	Game       *Game
+	OpponentID uuid.UUID
+	Player     int
+}
+
+type UserStats struct {
+	Total      int
+	Won        int
+	Lost       int
+	Draw       int
+	InProgress int
+}
@@ -0,0 +1,225 @@
+package notify
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/model"
+)
+
+const (
+	subscriberBuffer = 32
+	historySize      = 64
+	topicRetention   = 10 * time.Minute
+	sweepInterval    = time.Minute
+)
+
+const LobbyTopic = "lobby"
+
+const (
+	UpdateSnapshot     = "snapshot"
+	UpdateMove         = "move"
+	UpdateState        = "state"
+	UpdateChat         = "chat"
+	UpdateDrawOffer    = "draw_offer"
+	UpdateDrawDeclined = "draw_declined"
+	UpdateAbandoned    = "abandoned"
+	UpdateGameCreated  = "game_created"
+	UpdateQueue        = "queue"
+)
+
+type Update struct {
+	Seq     uint64
+	Type    string
+	GameID  uuid.UUID
+	Game    *model.Game
+	Move    *model.Move
+	UserID  uuid.UUID
+	Message string
+	Mode    string
+	Status  string
+	Size    int
+	Waiting int
+	Time    time.Time
+}
+
+type Subscription struct {
+	Updates <-chan Update
+	Missed  []Update
+	Seq     uint64
+	Resumed bool
+
+	close func()
+}
+
+func (s *Subscription) Close() {
+	s.close()
+}
+
+type subscriber struct {
+	updates chan Update
+}
+
+type topicState struct {
+	seq         uint64
+	history     []Update
+	lastUpdate  time.Time
+	subscribers map[*subscriber]struct{}
+}
+
+type Hub struct {
+	mu        sync.Mutex
+	topics    map[string]*topicState
+	lastSweep time.Time
+	closed    bool
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		topics:    make(map[string]*topicState),
+		lastSweep: time.Now(),
+	}
+}
+
+func GameTopic(gameID uuid.UUID) string {
+	return "game:" + gameID.String()
+}
+
+func (h *Hub) Publish(topic string, update Update) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	h.sweep(now)
+
+	state := h.topic(topic)
+	state.seq++
+	state.lastUpdate = now
+	update.Seq = state.seq
+	if update.Time.IsZero() {
+		update.Time = now
+	}
+
+	state.history = append(state.history, update)
+	if len(state.history) > historySize {
+		state.history = state.history[len(state.history)-historySize:]
+	}
+
+	for sub := range state.subscribers {
+		select {
+		case sub.updates <- update:
+		default:
+			delete(state.subscribers, sub)
+			close(sub.updates)
+		}
+	}
+}
+
+func (h *Hub) Subscribe(topic string, lastSeq uint64) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	state := h.topic(topic)
+	sub := &subscriber{updates: make(chan Update, subscriberBuffer)}
+	if h.closed {
+		close(sub.updates)
+	} else {
+		state.subscribers[sub] = struct{}{}
+	}
+
+	subscription := &Subscription{
+		Updates: sub.updates,
+		Seq:     state.seq,
+	}
+
+	if lastSeq > 0 && lastSeq <= state.seq {
+		missed, ok := state.since(lastSeq)
+		subscription.Missed = missed
+		subscription.Resumed = ok
+	}
+
+	var once sync.Once
+	subscription.close = func() {
+		once.Do(func() {
+			h.unsubscribe(topic, sub)
+		})
+	}
+
+	return subscription
+}
+
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, state := range h.topics {
+		for sub := range state.subscribers {
+			delete(state.subscribers, sub)
+			close(sub.updates)
+		}
+	}
+}
+
+func (h *Hub) Closed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.closed
+}
+
+func (h *Hub) unsubscribe(topic string, sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	state, ok := h.topics[topic]
+	if !ok {
+		return
+	}
+
+	if _, ok := state.subscribers[sub]; ok {
+		delete(state.subscribers, sub)
+		close(sub.updates)
+	}
+}
+
+func (h *Hub) topic(topic string) *topicState {
+	state, ok := h.topics[topic]
+	if !ok {
+		state = &topicState{
+			lastUpdate:  time.Now(),
+			subscribers: make(map[*subscriber]struct{}),
+		}
+		h.topics[topic] = state
+	}
+	return state
+}
+
+func (h *Hub) sweep(now time.Time) {
+	if now.Sub(h.lastSweep) < sweepInterval {
+		return
+	}
+	h.lastSweep = now
+
+	for name, state := range h.topics {
+		if len(state.subscribers) == 0 && now.Sub(state.lastUpdate) > topicRetention {
+			delete(h.topics, name)
+		}
+	}
+}
+
+func (t *topicState) since(lastSeq uint64) ([]Update, bool) {
+	if lastSeq == t.seq {
+		return nil, true
+	}
+
+	if len(t.history) == 0 || t.history[0].Seq > lastSeq+1 {
+		return nil, false
+	}
+
+	start := int(lastSeq + 1 - t.history[0].Seq)
+	missed := make([]Update, len(t.history)-start)
+	copy(missed, t.history[start:])
+
+	return missed, true
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"context"
+
+	"tictactoe/internal/domain/event"
+)
+
+type HubSubscriber struct {
+	hub *Hub
+}
+
+func NewHubSubscriber(hub *Hub) *HubSubscriber {
+	return &HubSubscriber{hub: hub}
+}
+
+func (s *HubSubscriber) Name() string {
+	return "push-streams"
+}
+
+func (s *HubSubscriber) Types() []event.Type {
+	return nil
+}
+
+func (s *HubSubscriber) Handle(ctx context.Context, ev event.Event) {
+	update := Update{
+		GameID:  ev.GameID,
+		Game:    ev.Game,
+		Move:    ev.Move,
+		UserID:  ev.UserID,
+		Message: ev.Message,
+		Time:    ev.OccurredAt,
+	}
+	topic := GameTopic(ev.GameID)
+
+	switch ev.Type {
+	case event.GameCreated:
+		update.Type = UpdateGameCreated
+		s.hub.Publish(LobbyTopic, lobbyUpdate(update))
+
+	case event.MoveMade:
+		update.Type = UpdateMove
+		s.hub.Publish(topic, update)
+
+	case event.MoveReverted:
+		update.Type = UpdateSnapshot
+		s.hub.Publish(topic, update)
+
+	case event.GameFinished:
+		update.Type = UpdateState
+		s.hub.Publish(topic, update)
+		s.hub.Publish(LobbyTopic, lobbyUpdate(update))
+
+	case event.GameAbandoned:
+		update.Type = UpdateAbandoned
+		s.hub.Publish(topic, update)
+
+	case event.ChatMessage:
+		update.Type = UpdateChat
+		s.hub.Publish(topic, update)
+
+	case event.DrawOffered:
+		update.Type = UpdateDrawOffer
+		s.hub.Publish(topic, update)
+
+	case event.DrawDeclined:
+		update.Type = UpdateDrawDeclined
+		s.hub.Publish(topic, update)
+	}
+}
+
+// lobbyUpdate keeps only the game summary: the lobby is open to every user, so
+// it must not carry boards, players or webhooks of other people's games.
+func lobbyUpdate(update Update) Update {
+	summary := Update{
+		Type:   update.Type,
+		GameID: update.GameID,
+		Time:   update.Time,
+	}
+	if update.Game != nil {
+		summary.Mode = update.Game.Mode
+		summary.Size = update.Game.Size
+		summary.Status = update.Game.State
+	}
+	return summary
+}
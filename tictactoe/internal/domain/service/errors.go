@@ -0,0 +1,32 @@
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrGameFinished       = errors.New("game is already finished")
+	ErrNotYourTurn        = errors.New("it is not your turn")
+	ErrAccessDenied       = errors.New("access denied: game belongs to another user")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrInvalidCredentials = errors.New("invalid login or password")
+	ErrAlreadyQueued      = errors.New("already waiting in matchmaking queue")
+	ErrShuttingDown       = errors.New("server is shutting down")
+	ErrEngineSaturated    = errors.New("engine is saturated")
+	ErrJobNotFound        = errors.New("AI job not found")
+	ErrRateLimited        = errors.New("rate limit exceeded")
+)
+
+type RetryError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/notify"
+)
+
+type matchResult struct {
+	game *model.Game
+	err  error
+}
+
+type matchTicket struct {
+	userID     uuid.UUID
+	rating     int
+	size       int
+	enqueuedAt time.Time
+	result     chan matchResult
+}
+
+type MatchmakingServiceImpl struct {
+	gameService  GameService
+	userService  UserService
+	hub          *notify.Hub
+	waitTimeout  time.Duration
+	ratingWindow int
+
+	mu      sync.Mutex
+	queues  map[int][]*matchTicket
+	waiting map[uuid.UUID]*matchTicket
+	closing bool
+}
+
+func NewMatchmakingService(gameService GameService, userService UserService, hub *notify.Hub,
+	waitTimeout time.Duration, ratingWindow int) MatchmakingService {
+	return &MatchmakingServiceImpl{
+		gameService:  gameService,
+		userService:  userService,
+		hub:          hub,
+		waitTimeout:  waitTimeout,
+		ratingWindow: ratingWindow,
+		queues:       make(map[int][]*matchTicket),
+		waiting:      make(map[uuid.UUID]*matchTicket),
+	}
+}
+
+func (s *MatchmakingServiceImpl) FindMatch(ctx context.Context, userID uuid.UUID, size int) (*model.Match, error) {
+	if size < model.MinFieldSize || size > model.MaxFieldSize {
+		return nil, fmt.Errorf("%w: size must be between %d and %d", ErrInvalidInput, model.MinFieldSize, model.MaxFieldSize)
+	}
+
+	user, err := s.userService.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := &matchTicket{
+		userID:     userID,
+		rating:     user.Rating,
+		size:       size,
+		enqueuedAt: time.Now(),
+		result:     make(chan matchResult, 1),
+	}
+
+	s.mu.Lock()
+	if s.closing {
+		s.mu.Unlock()
+		return nil, ErrShuttingDown
+	}
+
+	if _, queued := s.waiting[userID]; queued {
+		s.mu.Unlock()
+		return nil, ErrAlreadyQueued
+	}
+
+	if opponent := s.takeOpponent(ticket); opponent != nil {
+		s.publishQueue(size)
+		s.mu.Unlock()
+		return s.startMatch(ctx, opponent, ticket)
+	}
+
+	s.queues[size] = append(s.queues[size], ticket)
+	s.waiting[userID] = ticket
+	s.publishQueue(size)
+	s.mu.Unlock()
+
+	timer := time.NewTimer(s.waitTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-ticket.result:
+		return s.toMatch(result, userID)
+
+	case <-timer.C:
+		if !s.dequeue(ticket) {
+			return s.toMatch(<-ticket.result, userID)
+		}
+		game, err := s.gameService.CreateGame(ctx, model.GameOptions{Size: size, OwnerID: userID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create AI game: %w", err)
+		}
+		return &model.Match{Game: game, Player: model.PlayerX}, nil
+
+	case <-ctx.Done():
+		if !s.dequeue(ticket) {
+			return s.toMatch(<-ticket.result, userID)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (s *MatchmakingServiceImpl) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closing = true
+	for userID, ticket := range s.waiting {
+		delete(s.waiting, userID)
+		ticket.result <- matchResult{err: ErrShuttingDown}
+	}
+	for size := range s.queues {
+		delete(s.queues, size)
+		s.publishQueue(size)
+	}
+
+	return nil
+}
+
+func (s *MatchmakingServiceImpl) startMatch(ctx context.Context, waiting, joining *matchTicket) (*model.Match, error) {
+	game, err := s.gameService.CreateGame(context.WithoutCancel(ctx), model.GameOptions{
+		Size:       joining.size,
+		OwnerID:    waiting.userID,
+		OpponentID: joining.userID,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to create PvP game: %w", err)
+	}
+
+	waiting.result <- matchResult{game: game, err: err}
+
+	return s.toMatch(matchResult{game: game, err: err}, joining.userID)
+}
+
+func (s *MatchmakingServiceImpl) takeOpponent(ticket *matchTicket) *matchTicket {
+	queue := s.queues[ticket.size]
+
+	for i, candidate := range queue {
+		if abs(candidate.rating-ticket.rating) > s.ratingWindow {
+			continue
+		}
+
+		s.queues[ticket.size] = append(queue[:i:i], queue[i+1:]...)
+		delete(s.waiting, candidate.userID)
+		return candidate
+	}
+
+	return nil
+}
+
+func (s *MatchmakingServiceImpl) dequeue(ticket *matchTicket) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.waiting[ticket.userID] != ticket {
+		return false
+	}
+	delete(s.waiting, ticket.userID)
+
+	queue := s.queues[ticket.size]
+	for i, candidate := range queue {
+		if candidate == ticket {
+			s.queues[ticket.size] = append(queue[:i:i], queue[i+1:]...)
+			break
+		}
+	}
+	s.publishQueue(ticket.size)
+
+	return true
+}
+
+func (s *MatchmakingServiceImpl) publishQueue(size int) {
+	s.hub.Publish(notify.LobbyTopic, notify.Update{
+		Type:    notify.UpdateQueue,
+		Size:    size,
+		Waiting: len(s.queues[size]),
+	})
+}
+
+func (s *MatchmakingServiceImpl) toMatch(result matchResult, userID uuid.UUID) (*model.Match, error) {
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	game := result.game
+	opponentID := game.OpponentID
+	if userID == game.OpponentID {
+		opponentID = game.OwnerID
+	}
+
+	return &model.Match{
+		Game:       game,
+		OpponentID: opponentID,
+		Player:     game.PlayerNumber(userID),
+	}, nil
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"tictactoe/internal/datasource/repository"
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/notify"
+	"tictactoe/internal/metrics"
+)
+
+func newTestMatchmaking(t *testing.T, waitTimeout time.Duration) (*MatchmakingServiceImpl, UserService) {
+	t.Helper()
+
+	games, _ := newTestService(t)
+	users := NewUserService(repository.NewUserRepo(repository.NewUserStorage(), zap.NewNop(), metrics.New()))
+
+	return NewMatchmakingService(games, users, notify.NewHub(), waitTimeout, 200).(*MatchmakingServiceImpl), users
+}
+
+func registerTestUser(t *testing.T, users UserService, login string) uuid.UUID {
+	t.Helper()
+
+	user, err := users.Register(context.Background(), login, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user.ID
+}
+
+func TestFindMatchPairsWaitingPlayers(t *testing.T) {
+	s, users := newTestMatchmaking(t, 5*time.Second)
+	alice := registerTestUser(t, users, "alice")
+	bob := registerTestUser(t, users, "bob")
+
+	waiting := make(chan *model.Match, 1)
+	go func() {
+		match, err := s.FindMatch(context.Background(), alice, model.FieldSize)
+		if err != nil {
+			t.Error(err)
+		}
+		waiting <- match
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !queued(s, alice) {
+		if time.Now().After(deadline) {
+			t.Fatal("first player was not queued")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	joined, err := s.FindMatch(context.Background(), bob, model.FieldSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := <-waiting
+	if first == nil {
+		t.FailNow()
+	}
+
+	if first.Game.ID != joined.Game.ID {
+		t.Fatalf("players got games %s and %s, want the same game", first.Game.ID, joined.Game.ID)
+	}
+	if first.OpponentID != bob || joined.OpponentID != alice {
+		t.Errorf("opponents = %s and %s, want %s and %s", first.OpponentID, joined.OpponentID, bob, alice)
+	}
+	if first.Player == joined.Player {
+		t.Errorf("both players play %d", first.Player)
+	}
+	if queued(s, alice) || queued(s, bob) {
+		t.Error("matched players are still queued")
+	}
+}
+
+func TestFindMatchRemovesTimedOutPlayer(t *testing.T) {
+	s, users := newTestMatchmaking(t, 10*time.Millisecond)
+	alice := registerTestUser(t, users, "alice")
+
+	match, err := s.FindMatch(context.Background(), alice, model.FieldSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if match.Game.OpponentID != uuid.Nil || match.Player != model.PlayerX {
+		t.Errorf("got opponent %s as player %d, want an AI game as player %d", match.Game.OpponentID, match.Player, model.PlayerX)
+	}
+	if queued(s, alice) {
+		t.Error("timed out player is still queued")
+	}
+	if waiting := len(s.queues[model.FieldSize]); waiting != 0 {
+		t.Errorf("queue holds %d players, want 0", waiting)
+	}
+}
+
+func queued(s *MatchmakingServiceImpl, userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.waiting[userID]
+	return ok
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/model"
+)
+
+type MatchmakingService interface {
+	FindMatch(ctx context.Context, userID uuid.UUID, size int) (*model.Match, error)
+	Shutdown(ctx context.Context) error
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"tictactoe/internal/datasource/repository"
+	"tictactoe/internal/domain/event"
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/logging"
+)
+
+// RatingSubscriber updates the players' Elo ratings when a PvP game finishes.
+// Matchmaking pairs players by these ratings.
+type RatingSubscriber struct {
+	repo   repository.UserRepository
+	logger *zap.Logger
+}
+
+func NewRatingSubscriber(repo repository.UserRepository, logger *zap.Logger) *RatingSubscriber {
+	return &RatingSubscriber{repo: repo, logger: logger}
+}
+
+func (s *RatingSubscriber) Name() string {
+	return "ratings"
+}
+
+func (s *RatingSubscriber) Types() []event.Type {
+	return []event.Type{event.GameFinished}
+}
+
+// Reliable asks the event bus not to drop finished games: a lost event would
+// leave both ratings stale.
+func (s *RatingSubscriber) Reliable() bool {
+	return true
+}
+
+func (s *RatingSubscriber) Handle(ctx context.Context, ev event.Event) {
+	game := ev.Game
+	if game == nil || game.Mode != model.ModePvP {
+		return
+	}
+
+	logger := logging.FromContext(ctx, s.logger).With(zap.Stringer("game_id", game.ID))
+
+	owner, err := s.repo.Get(ctx, game.OwnerID)
+	if err != nil {
+		logger.Error("failed to get player for rating update", zap.Stringer("user_id", game.OwnerID), zap.Error(err))
+		return
+	}
+	opponent, err := s.repo.Get(ctx, game.OpponentID)
+	if err != nil {
+		logger.Error("failed to get player for rating update", zap.Stringer("user_id", game.OpponentID), zap.Error(err))
+		return
+	}
+
+	score := 0.5
+	switch game.WinnerNumber() {
+	case model.PlayerX:
+		score = 1
+	case model.PlayerO:
+		score = 0
+	}
+
+	change := model.RatingChange(owner.Rating, opponent.Rating, score)
+	if err := s.repo.AddRatings(ctx, map[uuid.UUID]int{
+		owner.ID:    change,
+		opponent.ID: -change,
+	}); err != nil {
+		logger.Error("failed to update ratings", zap.Error(err))
+		return
+	}
+
+	logger.Info("ratings updated",
+		zap.Stringer("owner_id", owner.ID),
+		zap.Int("owner_rating", owner.Rating+change),
+		zap.Stringer("opponent_id", opponent.ID),
+		zap.Int("opponent_rating", opponent.Rating-change))
+}
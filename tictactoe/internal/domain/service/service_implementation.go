@@ -2,52 +2,183 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"tictactoe/internal/datasource/repository"
 	"tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/event"
+	"tictactoe/internal/logging"
+	"tictactoe/internal/metrics"
+	"tictactoe/internal/requestid"
+	"tictactoe/internal/tracing"
+	"tictactoe/internal/webhook"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
+const (
+	maxChatMessageLength = 500
+	maxGameWebhooks      = 5
+	aiJobRetention       = 10 * time.Minute
+)
+
+var tracer = otel.Tracer("tictactoe/internal/domain/service")
+
 type GameServiceImpl struct {
-	repo repository.GameRepository
-	algo MinimaxAlgorithm
+	repo     repository.GameRepository
+	algo     MinimaxAlgorithm
+	executor SearchExecutor
+	bus      *event.Bus
+	logger   *zap.Logger
+	metrics  *metrics.Metrics
+
+	mu             sync.Mutex
+	closing        bool
+	jobs           map[uuid.UUID]*model.AIJob
+	searches       sync.WaitGroup
+	searchCtx      context.Context
+	cancelSearches context.CancelFunc
 }
 
-func NewGameService(repo repository.GameRepository, algo MinimaxAlgorithm) GameService {
+func NewGameService(repo repository.GameRepository, algo MinimaxAlgorithm, executor SearchExecutor, bus *event.Bus,
+	logger *zap.Logger, m *metrics.Metrics) GameService {
+	searchCtx, cancelSearches := context.WithCancel(context.Background())
 	return &GameServiceImpl{
-		repo: repo,
-		algo: algo,
+		repo:           repo,
+		algo:           algo,
+		executor:       executor,
+		bus:            bus,
+		logger:         logger,
+		metrics:        m,
+		jobs:           make(map[uuid.UUID]*model.AIJob),
+		searchCtx:      searchCtx,
+		cancelSearches: cancelSearches,
+	}
+}
+
+func (s *GameServiceImpl) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closing = true
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.searches.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.logger.Warn("engine searches did not finish in time, cancelling")
+	s.cancelSearches()
+	<-done
+
+	return nil
+}
+
+func (s *GameServiceImpl) CheckEngine(ctx context.Context) error {
+	if s.executor.Saturated() {
+		return fmt.Errorf("%w: search queue is full", ErrEngineSaturated)
+	}
+	return nil
+}
+
+func (s *GameServiceImpl) CheckShutdown(ctx context.Context) error {
+	if s.isClosing() {
+		return ErrShuttingDown
 	}
+	return nil
+}
+
+func (s *GameServiceImpl) isClosing() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closing
 }
 
 func (s *GameServiceImpl) GetNextMove(ctx context.Context, gameID uuid.UUID) (*model.Game, error) {
-	game, err := s.repo.Get(ctx, gameID)
+	if err := s.reserveSearch(); err != nil {
+		return nil, err
+	}
+	
+	return s.playAIMove(ctx, gameID)
+}
+
+func (s *GameServiceImpl) reserveSearch() error {
+	if s.isClosing() {
+		return ErrShuttingDown
+	}
+	
+	if err := s.executor.Reserve(); err != nil {
+		return &RetryError{
+			Err:        fmt.Errorf("%w: %v", ErrEngineSaturated, err),
+			RetryAfter: s.executor.RetryAfter(),
+		}
+	}
+	return nil
+}
+
+// playAIMove consumes a search slot taken with reserveSearch: the slot is either
+// used by FindBestMove or released.
+func (s *GameServiceImpl) playAIMove(ctx context.Context, gameID uuid.UUID) (game *model.Game, err error) {
+	ctx, span := tracer.Start(ctx, "GameService.GetNextMove", trace.WithAttributes(tracing.GameIDKey.String(gameID.String())))
+	defer func() { tracing.End(span, err) }()
+	
+	searched := false
+	defer func() {
+		if !searched {
+			s.executor.Release()
+		}
+	}()
+	
+	game, err = s.repo.Get(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get game: %w", err)
 	}
+	span.SetAttributes(tracing.BoardSizeKey.Int(game.Size))
 
-	row, col := s.algo.FindBestMove(game)
+	if game.Mode == model.ModePvP {
+		return nil, fmt.Errorf("%w: game has no AI opponent", model.ErrInvalidMove)
+	}
 	
-	if err := game.MakeMove(row, col, 2); err != nil {
+	if game.IsFinished() {
+		return nil, ErrGameFinished
+	}
+	
+	if game.PlayerTurn != model.PlayerO {
+		return nil, fmt.Errorf("%w: it is not AI's turn", model.ErrInvalidMove)
+	}
+
+	searched = true
+	row, col, err := s.FindBestMove(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := game.MakeMove(row, col, model.PlayerO); err != nil {
 		return nil, fmt.Errorf("move AI failed: %w", err)
 	}
 
-	if winner := game.CheckWinner(); winner != 0 {
-        if winner == 1 {
-            game.State = model.StatePlayerWon
-        } else {
-            game.State = model.StateAIWon
-        }
-    } else if game.IsFull() {
-        game.State = model.StateDraw
-    }
+	game.UpdateState()
 	
 	if err := s.repo.Save(ctx, game); err != nil {
 		return nil, fmt.Errorf("failed to save game: %w", err)
 	}
 	
+	s.publishMove(ctx, game, model.Move{Row: row, Col: col, Player: model.PlayerO})
+	
 	return game, nil
 }
 
@@ -109,60 +240,524 @@ func (s *GameServiceImpl) GetGameState(ctx context.Context, gameID uuid.UUID) (s
 }
 
 func (s *GameServiceImpl) MakePlayerMove(ctx context.Context, gameID uuid.UUID, row, col, player int) (*model.Game, error) {
-	game, err := s.repo.Get(ctx, gameID)
+	return s.makePlayerMove(ctx, gameID, row, col, player, uuid.Nil)
+}
+
+// makePlayerMove saves the player's move; a non-nil jobID marks the game as
+// waiting for that AI job in the same save.
+func (s *GameServiceImpl) makePlayerMove(ctx context.Context, gameID uuid.UUID, row, col, player int, jobID uuid.UUID) (game *model.Game, err error) {
+	ctx, span := tracer.Start(ctx, "GameService.MakePlayerMove", trace.WithAttributes(tracing.GameIDKey.String(gameID.String())))
+	defer func() { tracing.End(span, err) }()
+	
+	game, err = s.repo.Get(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get game: %w", err)
 	}
+	span.SetAttributes(tracing.BoardSizeKey.Int(game.Size))
+	
+	if game.IsFinished() {
+		return nil, ErrGameFinished
+	}
 	
-	if game.State != model.StateInProgress {
-		return nil, fmt.Errorf("game is already finished")
+	if game.PlayerTurn != player {
+		return nil, ErrNotYourTurn
 	}
 	
 	if err := game.MakeMove(row, col, player); err != nil {
 		return nil, fmt.Errorf("move failed: %w", err)
 	}
 
-	if winner := game.CheckWinner(); winner != 0 {
-        if winner == 1 {
-            game.State = model.StatePlayerWon
-        } else {
-            game.State = model.StateAIWon
-        }
-    } else if game.IsFull() {
-        game.State = model.StateDraw
-    }
+	game.UpdateState()
+	if jobID != uuid.Nil && !game.IsFinished() {
+		game.StartAIJob(jobID)
+	}
+	
+	if err := s.repo.Save(ctx, game); err != nil {
+		return nil, fmt.Errorf("failed to save game: %w", err)
+	}
+	
+	s.publishMove(ctx, game, model.Move{Row: row, Col: col, Player: player})
+	
+	return game, nil
+}
+
+func (s *GameServiceImpl) PlayMove(ctx context.Context, gameID, userID uuid.UUID, row, col int) (game *model.Game, err error) {
+	ctx, span := tracer.Start(ctx, "GameService.PlayMove", trace.WithAttributes(
+		tracing.GameIDKey.String(gameID.String()),
+		tracing.UserIDKey.String(userID.String())))
+	defer func() { tracing.End(span, err) }()
+	
+	game, reserved, err := s.applyPlayerMove(ctx, gameID, userID, row, col, uuid.Nil)
+	if err != nil || !reserved {
+		return game, err
+	}
+	
+	move := game.Moves[len(game.Moves)-1]
+	game, err = s.playAIMove(ctx, gameID)
+	if err != nil {
+		if err := s.revertPlayerMove(context.WithoutCancel(ctx), gameID, uuid.Nil, move); err != nil {
+			logging.FromContext(ctx, s.logger).Error("failed to revert player move", zap.Stringer("game_id", gameID), zap.Error(err))
+		}
+		return nil, err
+	}
+	
+	return game, nil
+}
+
+func (s *GameServiceImpl) PlayMoveAsync(ctx context.Context, gameID, userID uuid.UUID, row, col int) (game *model.Game, job *model.AIJob, err error) {
+	ctx, span := tracer.Start(ctx, "GameService.PlayMoveAsync", trace.WithAttributes(
+		tracing.GameIDKey.String(gameID.String()),
+		tracing.UserIDKey.String(userID.String())))
+	defer func() { tracing.End(span, err) }()
+	
+	job, err = s.newAIJob(gameID)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	game, reserved, err := s.applyPlayerMove(ctx, gameID, userID, row, col, job.ID)
+	if err != nil || !reserved {
+		s.dropAIJob(job.ID)
+		return game, nil, err
+	}
+	
+	go s.runAIJob(context.WithoutCancel(ctx), job.ID, gameID, game.Moves[len(game.Moves)-1])
+	
+	snapshot := *job
+	return game, &snapshot, nil
+}
+
+// applyPlayerMove saves the player's move. In an AI game that goes on it returns
+// with a search slot reserved for the AI reply.
+func (s *GameServiceImpl) applyPlayerMove(ctx context.Context, gameID, userID uuid.UUID, row, col int, jobID uuid.UUID) (game *model.Game, reserved bool, err error) {
+	game, err = s.getParticipantGame(ctx, gameID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	
+	player := game.PlayerNumber(userID)
+	if game.Mode == model.ModePvP {
+		game, err = s.MakePlayerMove(ctx, gameID, row, col, player)
+		return game, false, err
+	}
+	
+	if err := s.reserveSearch(); err != nil {
+		return nil, false, err
+	}
+	
+	game, err = s.makePlayerMove(ctx, gameID, row, col, player, jobID)
+	if err != nil {
+		s.executor.Release()
+		return nil, false, err
+	}
+	
+	if game.IsFinished() {
+		s.executor.Release()
+		return game, false, nil
+	}
+	
+	return game, true, nil
+}
+
+func (s *GameServiceImpl) GetAIJob(ctx context.Context, gameID, userID, jobID uuid.UUID) (*model.AIJob, error) {
+	if _, err := s.getParticipantGame(ctx, gameID, userID); err != nil {
+		return nil, err
+	}
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	job, ok := s.jobs[jobID]
+	if !ok || job.GameID != gameID {
+		return nil, ErrJobNotFound
+	}
+	
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// newAIJob registers a pending job and counts it as a running search, so
+// Shutdown waits until its move is saved.
+func (s *GameServiceImpl) newAIJob(gameID uuid.UUID) (*model.AIJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	if s.closing {
+		return nil, ErrShuttingDown
+	}
+	
+	now := time.Now()
+	for id, job := range s.jobs {
+		if job.IsFinished() && now.Sub(job.FinishedAt) > aiJobRetention {
+			delete(s.jobs, id)
+		}
+	}
+	
+	job := &model.AIJob{
+		ID:        uuid.New(),
+		GameID:    gameID,
+		Status:    model.JobPending,
+		CreatedAt: now,
+	}
+	s.jobs[job.ID] = job
+	s.searches.Add(1)
+	
+	return job, nil
+}
+
+func (s *GameServiceImpl) dropAIJob(jobID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.jobs, jobID)
+	s.mu.Unlock()
+	
+	s.searches.Done()
+}
+
+// runAIJob plays the AI reply to playerMove. If the AI cannot move, playerMove
+// is taken back so the game does not stay on the AI's turn.
+func (s *GameServiceImpl) runAIJob(ctx context.Context, jobID, gameID uuid.UUID, playerMove model.Move) {
+	defer s.searches.Done()
+	
+	logger := logging.FromContext(ctx, s.logger).With(
+		zap.Stringer("game_id", gameID),
+		zap.Stringer("job_id", jobID))
+	
+	game, err := s.playAIMove(ctx, gameID)
+	if err != nil {
+		logger.Error("AI job failed", zap.Error(err))
+		if err := s.revertPlayerMove(ctx, gameID, jobID, playerMove); err != nil {
+			logger.Error("failed to revert player move", zap.Error(err))
+		}
+	}
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	job := s.jobs[jobID]
+	job.FinishedAt = time.Now()
+	if err != nil {
+		job.Status = model.JobFailed
+		job.Error = err.Error()
+		return
+	}
+	
+	move := game.Moves[len(game.Moves)-1]
+	job.Status = model.JobDone
+	job.Move = &move
+}
+
+// revertPlayerMove hands the turn back to the player after the AI failed to
+// answer move. It does nothing if the game has moved on since.
+func (s *GameServiceImpl) revertPlayerMove(ctx context.Context, gameID, jobID uuid.UUID, move model.Move) error {
+	game, err := s.repo.Get(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to get game: %w", err)
+	}
+	
+	if game.AIJobID != jobID || game.PlayerTurn != model.PlayerO || !game.UndoMove(move) {
+		return nil
+	}
+	
+	if err := s.repo.Save(ctx, game); err != nil {
+		return fmt.Errorf("failed to save game: %w", err)
+	}
+	
+	s.publish(ctx, event.Event{
+		Type:   event.MoveReverted,
+		GameID: game.ID,
+		Game:   game.DeepCopy(),
+		Move:   &move,
+		UserID: game.PlayerID(move.Player),
+	})
+	return nil
+}
+
+func (s *GameServiceImpl) SendChat(ctx context.Context, gameID, userID uuid.UUID, message string) error {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return fmt.Errorf("%w: chat message is empty", ErrInvalidInput)
+	}
+	if len(message) > maxChatMessageLength {
+		return fmt.Errorf("%w: chat message is too long: max %d characters", ErrInvalidInput, maxChatMessageLength)
+	}
+	
+	if _, err := s.getParticipantGame(ctx, gameID, userID); err != nil {
+		return err
+	}
+	
+	s.publish(ctx, event.Event{
+		Type:    event.ChatMessage,
+		GameID:  gameID,
+		UserID:  userID,
+		Message: message,
+	})
+	
+	return nil
+}
+
+func (s *GameServiceImpl) OfferDraw(ctx context.Context, gameID, userID uuid.UUID) (*model.Game, error) {
+	game, err := s.getParticipantGame(ctx, gameID, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if game.Mode != model.ModePvP {
+		return nil, fmt.Errorf("%w: draw offers are only available in PvP games", ErrInvalidInput)
+	}
+	
+	if game.IsFinished() {
+		return nil, ErrGameFinished
+	}
+	
+	if game.DrawOfferedBy != uuid.Nil {
+		return nil, fmt.Errorf("%w: draw has already been offered", model.ErrConflict)
+	}
+	
+	game.DrawOfferedBy = userID
+	game.UpdatedAt = time.Now()
 	
 	if err := s.repo.Save(ctx, game); err != nil {
 		return nil, fmt.Errorf("failed to save game: %w", err)
 	}
 	
+	s.publish(ctx, event.Event{
+		Type:   event.DrawOffered,
+		GameID: gameID,
+		Game:   game.DeepCopy(),
+		UserID: userID,
+	})
+	
 	return game, nil
 }
 
-func (s *GameServiceImpl) CreateGame(ctx context.Context, size int) (*model.Game, error) {
-	if size < 3 || size > 10 {
-		return nil, fmt.Errorf("invalid size: must be between 3 and 10")
+func (s *GameServiceImpl) RespondDraw(ctx context.Context, gameID, userID uuid.UUID, accept bool) (*model.Game, error) {
+	game, err := s.getParticipantGame(ctx, gameID, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if game.IsFinished() {
+		return nil, ErrGameFinished
+	}
+	
+	if game.DrawOfferedBy == uuid.Nil || game.DrawOfferedBy == userID {
+		return nil, fmt.Errorf("%w: no draw offer to respond to", model.ErrConflict)
 	}
 	
-	field := make(model.GameField, size)
-	for i := range field {
-		field[i] = make([]int, size)
+	game.DrawOfferedBy = uuid.Nil
+	game.UpdatedAt = time.Now()
+	if accept {
+		game.State = model.StateDraw
 	}
 	
-	game := &model.Game{
+	if err := s.repo.Save(ctx, game); err != nil {
+		return nil, fmt.Errorf("failed to save game: %w", err)
+	}
+	
+	ev := event.Event{
+		Type:   event.DrawDeclined,
+		GameID: gameID,
+		Game:   game.DeepCopy(),
+		UserID: userID,
+	}
+	if accept {
+		ev.Type = event.GameFinished
+	}
+	s.publish(ctx, ev)
+	
+	return game, nil
+}
+
+func (s *GameServiceImpl) AbandonGame(ctx context.Context, gameID, userID uuid.UUID) (*model.Game, error) {
+	game, err := s.getParticipantGame(ctx, gameID, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if game.IsFinished() {
+		return nil, ErrGameFinished
+	}
+	
+	game.Forfeit(game.PlayerNumber(userID))
+	
+	if err := s.repo.Save(ctx, game); err != nil {
+		return nil, fmt.Errorf("failed to save game: %w", err)
+	}
+	
+	snapshot := game.DeepCopy()
+	s.publish(ctx, event.Event{
+		Type:   event.GameAbandoned,
+		GameID: gameID,
+		Game:   snapshot,
+		UserID: userID,
+	})
+	s.publish(ctx, event.Event{
+		Type:   event.GameFinished,
+		GameID: gameID,
+		Game:   snapshot,
+	})
+	
+	return game, nil
+}
+
+func (s *GameServiceImpl) validateWebhooks(webhooks []model.Webhook) ([]model.Webhook, error) {
+	if len(webhooks) > maxGameWebhooks {
+		return nil, fmt.Errorf("%w: at most %d webhooks per game", ErrInvalidInput, maxGameWebhooks)
+	}
+	
+	validated := make([]model.Webhook, 0, len(webhooks))
+	for _, hook := range webhooks {
+		if err := webhook.CheckURL(hook.URL); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		
+		events := hook.Events
+		if len(events) == 0 {
+			events = []string{string(event.GameFinished)}
+		}
+		for _, name := range events {
+			if !event.IsKnownType(name) {
+				return nil, fmt.Errorf("%w: unknown webhook event %q", ErrInvalidInput, name)
+			}
+		}
+		
+		secret := hook.Secret
+		if secret == "" {
+			var err error
+			secret, err = generateSecret()
+			if err != nil {
+				return nil, err
+			}
+		}
+		
+		validated = append(validated, model.Webhook{
+			URL:    hook.URL,
+			Events: events,
+			Secret: secret,
+		})
+	}
+	
+	return validated, nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func (s *GameServiceImpl) getParticipantGame(ctx context.Context, gameID, userID uuid.UUID) (*model.Game, error) {
+	game, err := s.repo.Get(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	
+	if !game.IsParticipant(userID) {
+		return nil, ErrAccessDenied
+	}
+	
+	return game, nil
+}
+
+func (s *GameServiceImpl) publish(ctx context.Context, ev event.Event) {
+	ev.RequestID = requestid.FromContext(ctx)
+	
+	switch ev.Type {
+	case event.GameCreated:
+		s.metrics.GameCreated(ev.Game.Mode, ev.Game.Size)
+	case event.GameFinished:
+		s.metrics.GameFinished(gameOutcome(ev.Game), ev.Game.Mode, ev.Game.Size)
+	}
+	
+	s.bus.Publish(ctx, ev)
+}
+
+func gameOutcome(game *model.Game) string {
+	switch game.WinnerNumber() {
+	case model.PlayerX:
+		return "x_won"
+	case model.PlayerO:
+		return "o_won"
+	}
+	return "draw"
+}
+
+func (s *GameServiceImpl) publishMove(ctx context.Context, game *model.Game, move model.Move) {
+	snapshot := game.DeepCopy()
+	
+	s.publish(ctx, event.Event{
+		Type:   event.MoveMade,
+		GameID: game.ID,
+		Game:   snapshot,
+		Move:   &move,
+		UserID: game.PlayerID(move.Player),
+	})
+	
+	if game.IsFinished() {
+		s.publish(ctx, event.Event{
+			Type:   event.GameFinished,
+			GameID: game.ID,
+			Game:   snapshot,
+		})
+	}
+}
+
+func (s *GameServiceImpl) CreateGame(ctx context.Context, opts model.GameOptions) (game *model.Game, err error) {
+	ctx, span := tracer.Start(ctx, "GameService.CreateGame", trace.WithAttributes(
+		tracing.BoardSizeKey.Int(opts.Size),
+		tracing.UserIDKey.String(opts.OwnerID.String())))
+	defer func() { tracing.End(span, err) }()
+	
+	if s.isClosing() {
+		return nil, ErrShuttingDown
+	}
+	
+	if opts.Size < model.MinFieldSize || opts.Size > model.MaxFieldSize {
+		return nil, fmt.Errorf("%w: size must be between %d and %d", ErrInvalidInput, model.MinFieldSize, model.MaxFieldSize)
+	}
+	
+	mode := model.ModeAI
+	if opts.OpponentID != uuid.Nil {
+		if opts.OpponentID == opts.OwnerID {
+			return nil, fmt.Errorf("%w: cannot play against yourself", ErrInvalidInput)
+		}
+		mode = model.ModePvP
+	}
+	
+	webhooks, err := s.validateWebhooks(opts.Webhooks)
+	if err != nil {
+		return nil, err
+	}
+	
+	game = &model.Game{
 		ID:         uuid.New(),
-		Field:      field,
+		OwnerID:    opts.OwnerID,
+		OpponentID: opts.OpponentID,
+		Mode:       mode,
+		Webhooks:   webhooks,
+		Field:      model.NewField(opts.Size),
 		State:      model.StateInProgress,
-		PlayerTurn: 1,
-		Size:       size,
+		PlayerTurn: model.PlayerX,
+		Size:       opts.Size,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
 	
+	span.SetAttributes(tracing.GameIDKey.String(game.ID.String()))
+	
 	if err := s.repo.Save(ctx, game); err != nil {
 		return nil, fmt.Errorf("failed to save game: %w", err)
 	}
 	
+	s.publish(ctx, event.Event{
+		Type:   event.GameCreated,
+		GameID: game.ID,
+		Game:   game.DeepCopy(),
+		UserID: game.OwnerID,
+	})
+	
 	return game, nil
 }
 
@@ -170,6 +765,87 @@ func (s *GameServiceImpl) GetGame(ctx context.Context, gameID uuid.UUID) (*model
 	return s.repo.Get(ctx, gameID)
 }
 
-func (s *GameServiceImpl) FindBestMove(game *model.Game) (row, col int) {
-	return s.algo.FindBestMove(game)
+func (s *GameServiceImpl) GetUserGames(ctx context.Context, userID uuid.UUID) ([]*model.Game, error) {
+	games, err := s.repo.GetByPlayer(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user games: %w", err)
+	}
+	
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].CreatedAt.After(games[j].CreatedAt)
+	})
+	
+	return games, nil
+}
+
+func (s *GameServiceImpl) GetUserStats(ctx context.Context, userID uuid.UUID) (*model.UserStats, error) {
+	games, err := s.repo.GetByPlayer(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user games: %w", err)
+	}
+	
+	stats := &model.UserStats{Total: len(games)}
+	for _, game := range games {
+		switch {
+		case !game.IsFinished():
+			stats.InProgress++
+		case game.State == model.StateDraw:
+			stats.Draw++
+		case game.WinnerNumber() == game.PlayerNumber(userID):
+			stats.Won++
+		default:
+			stats.Lost++
+		}
+	}
+	
+	return stats, nil
+}
+
+func (s *GameServiceImpl) FindBestMove(ctx context.Context, game *model.Game) (row, col int, err error) {
+	searchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stop := context.AfterFunc(s.searchCtx, cancel)
+	defer stop()
+	
+	s.mu.Lock()
+	tracked := !s.closing
+	if tracked {
+		s.searches.Add(1)
+	}
+	s.mu.Unlock()
+	
+	if tracked {
+		defer s.searches.Done()
+	} else {
+		cancel()
+	}
+	
+	logger := logging.FromContext(ctx, s.logger).With(zap.Stringer("game_id", game.ID))
+	started := time.Now()
+	
+	var searchErr error
+	err = s.executor.Run(searchCtx, func(ctx context.Context) {
+		row, col, searchErr = s.algo.FindBestMove(ctx, game)
+	})
+	if err != nil {
+		return -1, -1, fmt.Errorf("%w: %v", ErrShuttingDown, err)
+	}
+	// searchCtx is only cancelled when the service stops; the per-search
+	// timeout is applied by the executor inside Run.
+	if searchCtx.Err() != nil {
+		return -1, -1, fmt.Errorf("%w: engine search cancelled", ErrShuttingDown)
+	}
+	if searchErr != nil {
+		logger.Warn("engine search interrupted, playing the best move found so far", zap.Error(searchErr))
+	}
+	if row < 0 || col < 0 {
+		return -1, -1, fmt.Errorf("%w: no free cell for the AI move", model.ErrInvalidMove)
+	}
+	
+	logger.Debug("AI move",
+		zap.Int("row", row),
+		zap.Int("col", col),
+		zap.Duration("duration", time.Since(started)))
+	
+	return row, col, nil
 }
\ No newline at end of file
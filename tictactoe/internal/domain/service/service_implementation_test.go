@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"tictactoe/internal/datasource/repository"
+	"tictactoe/internal/domain/event"
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/metrics"
+)
+
+var errExecutorClosed = errors.New("executor closed")
+
+// closedExecutor accepts reservations but fails every search, as the engine
+// does while the server shuts down.
+type closedExecutor struct{}
+
+func (closedExecutor) Reserve() error { return nil }
+
+func (closedExecutor) Run(ctx context.Context, task func(ctx context.Context)) error {
+	return errExecutorClosed
+}
+
+func (closedExecutor) Release() {}
+
+func (closedExecutor) Saturated() bool { return false }
+
+func (closedExecutor) RetryAfter() time.Duration { return 0 }
+
+// inlineExecutor runs every search on the caller's goroutine.
+type inlineExecutor struct{ closedExecutor }
+
+func (inlineExecutor) Run(ctx context.Context, task func(ctx context.Context)) error {
+	task(ctx)
+	return nil
+}
+
+// cancelledSearch stops as soon as its context is done, before finding a move.
+type cancelledSearch struct{}
+
+func (cancelledSearch) FindBestMove(ctx context.Context, game *model.Game) (row, col int, err error) {
+	<-ctx.Done()
+	return -1, -1, ctx.Err()
+}
+
+type firstFreeCell struct{}
+
+func (firstFreeCell) FindBestMove(ctx context.Context, game *model.Game) (row, col int, err error) {
+	for i := range game.Field {
+		for j := range game.Field[i] {
+			if game.Field.IsEmpty(i, j) {
+				return i, j, nil
+			}
+		}
+	}
+	return -1, -1, nil
+}
+
+func newTestService(t *testing.T) (*GameServiceImpl, *model.Game) {
+	t.Helper()
+
+	logger := zap.NewNop()
+	m := metrics.New()
+	repo := repository.NewGameRepo(repository.NewGameStorage(0), logger, m)
+	bus := event.NewBus(event.DefaultBufferSize, 1, logger, m)
+	t.Cleanup(func() { bus.Close(context.Background()) })
+
+	s := NewGameService(repo, firstFreeCell{}, closedExecutor{}, bus, logger, m).(*GameServiceImpl)
+	game, err := s.CreateGame(context.Background(), model.GameOptions{Size: model.FieldSize, OwnerID: uuid.New()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, game
+}
+
+func assertTurnHandedBack(t *testing.T, s *GameServiceImpl, gameID uuid.UUID) {
+	t.Helper()
+
+	game, err := s.GetGame(context.Background(), gameID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.PlayerTurn != model.PlayerX {
+		t.Errorf("PlayerTurn = %d, want %d", game.PlayerTurn, model.PlayerX)
+	}
+	if game.State != model.StateInProgress || game.AIJobID != uuid.Nil {
+		t.Errorf("State = %q, AIJobID = %s, want %q without a job", game.State, game.AIJobID, model.StateInProgress)
+	}
+	if len(game.Moves) != 0 || !game.Field.IsEmpty(0, 0) {
+		t.Errorf("Moves = %v, want the player's move taken back", game.Moves)
+	}
+}
+
+func TestPlayMoveAsyncRevertsMoveWhenAIFails(t *testing.T) {
+	s, game := newTestService(t)
+	ctx := context.Background()
+
+	_, job, err := s.PlayMoveAsync(ctx, game.ID, game.OwnerID, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err = s.GetAIJob(ctx, game.ID, game.OwnerID, job.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.IsFinished() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("AI job did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if job.Status != model.JobFailed {
+		t.Errorf("job status = %q, want %q", job.Status, model.JobFailed)
+	}
+	assertTurnHandedBack(t, s, game.ID)
+
+	if _, _, err := s.PlayMoveAsync(ctx, game.ID, game.OwnerID, 0, 0); err != nil {
+		t.Errorf("player cannot move again after the AI failed: %v", err)
+	}
+}
+
+func TestPlayMoveRevertsMoveWhenAIFails(t *testing.T) {
+	s, game := newTestService(t)
+
+	if _, err := s.PlayMove(context.Background(), game.ID, game.OwnerID, 0, 0); !errors.Is(err, ErrShuttingDown) {
+		t.Fatalf("PlayMove() = %v, want %v", err, ErrShuttingDown)
+	}
+	assertTurnHandedBack(t, s, game.ID)
+}
+
+func TestFindBestMoveReportsShutdown(t *testing.T) {
+	s, game := newTestService(t)
+	s.algo = cancelledSearch{}
+	s.executor = inlineExecutor{}
+	s.cancelSearches()
+
+	if _, _, err := s.FindBestMove(context.Background(), game); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("FindBestMove() = %v, want %v", err, ErrShuttingDown)
+	}
+}
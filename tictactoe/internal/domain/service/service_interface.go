@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 	"context"
 	"tictactoe/internal/domain/model"
+	"time"
 )
 
 type GameService interface {
@@ -11,10 +12,30 @@ type GameService interface {
 	ValidateField(ctx context.Context, gameID uuid.UUID, field model.GameField) (bool, error)
 	GetGameState(ctx context.Context, gameID uuid.UUID) (string, error)
 	MakePlayerMove(ctx context.Context, gameID uuid.UUID, row, col, player int) (*model.Game, error)
-    CreateGame(ctx context.Context, size int) (*model.Game, error) 
+    CreateGame(ctx context.Context, opts model.GameOptions) (*model.Game, error) 
     GetGame(ctx context.Context, gameID uuid.UUID) (*model.Game, error)
+    GetUserGames(ctx context.Context, userID uuid.UUID) ([]*model.Game, error)
+    GetUserStats(ctx context.Context, userID uuid.UUID) (*model.UserStats, error)
+    PlayMove(ctx context.Context, gameID, userID uuid.UUID, row, col int) (*model.Game, error)
+    PlayMoveAsync(ctx context.Context, gameID, userID uuid.UUID, row, col int) (*model.Game, *model.AIJob, error)
+    GetAIJob(ctx context.Context, gameID, userID, jobID uuid.UUID) (*model.AIJob, error)
+    SendChat(ctx context.Context, gameID, userID uuid.UUID, message string) error
+    OfferDraw(ctx context.Context, gameID, userID uuid.UUID) (*model.Game, error)
+    RespondDraw(ctx context.Context, gameID, userID uuid.UUID, accept bool) (*model.Game, error)
+    AbandonGame(ctx context.Context, gameID, userID uuid.UUID) (*model.Game, error)
+    Shutdown(ctx context.Context) error
+    CheckEngine(ctx context.Context) error
+    CheckShutdown(ctx context.Context) error
+}
+
+type SearchExecutor interface {
+    Reserve() error
+    Run(ctx context.Context, task func(ctx context.Context)) error
+    Release()
+    Saturated() bool
+    RetryAfter() time.Duration
 }
 
 type MinimaxAlgorithm interface {
-    FindBestMove(game *model.Game) (row, col int)
+    FindBestMove(ctx context.Context, game *model.Game) (row, col int, err error)
 }
\ No newline at end of file
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"tictactoe/internal/datasource/repository"
+	"tictactoe/internal/domain/model"
+)
+
+const (
+	minLoginLength    = 3
+	maxLoginLength    = 32
+	minPasswordLength = 6
+	maxPasswordLength = 72
+)
+
+// dummyPasswordHash is compared against when the login does not exist, so a
+// failed login takes as long as a wrong password and does not reveal logins.
+// It has the same cost as the hashes Register stores.
+const dummyPasswordHash = "$2a$10$NjZ1jIC7jgA9sDpyhGjOae0tCvo.nROh1lGXlYMJ.O7pTH8UNXbum"
+
+type UserServiceImpl struct {
+	repo repository.UserRepository
+}
+
+func NewUserService(repo repository.UserRepository) UserService {
+	return &UserServiceImpl{repo: repo}
+}
+
+func (s *UserServiceImpl) Register(ctx context.Context, login, password string) (*model.User, error) {
+	login = strings.TrimSpace(login)
+
+	if len(login) < minLoginLength || len(login) > maxLoginLength {
+		return nil, fmt.Errorf("%w: login length must be between %d and %d", ErrInvalidInput, minLoginLength, maxLoginLength)
+	}
+
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return nil, fmt.Errorf("%w: password length must be between %d and %d", ErrInvalidInput, minPasswordLength, maxPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &model.User{
+		ID:           uuid.New(),
+		Login:        login,
+		PasswordHash: string(hash),
+		Rating:       model.DefaultRating,
+		CreatedAt:    time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *UserServiceImpl) Authenticate(ctx context.Context, login, password string) (*model.User, error) {
+	user, err := s.repo.GetByLogin(ctx, strings.TrimSpace(login))
+	if err != nil {
+		bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return user, nil
+}
+
+func (s *UserServiceImpl) GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
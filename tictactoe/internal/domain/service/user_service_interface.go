@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/model"
+)
+
+type UserService interface {
+	Register(ctx context.Context, login, password string) (*model.User, error)
+	Authenticate(ctx context.Context, login, password string) (*model.User, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error)
+}
@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Check is one dependency of readiness. Details, when set, adds information
+// to the report that never fails the check.
+type Check struct {
+	Name    string
+	Run     func(ctx context.Context) error
+	Details func() map[string]any
+}
+
+type Result struct {
+	Name     string
+	Status   string
+	Error    error
+	Details  map[string]any
+	Duration time.Duration
+}
+
+type Report struct {
+	Status  string
+	Results []Result
+}
+
+func (r Report) Healthy() bool {
+	return r.Status == StatusOK
+}
+
+type Checker struct {
+	checks  []Check
+	timeout time.Duration
+}
+
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+func (c *Checker) Run(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Results: results}
+	for _, result := range results {
+		if result.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+func run(ctx context.Context, check Check) Result {
+	started := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Run(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{Name: check.Name, Status: StatusOK, Error: err, Duration: time.Since(started)}
+	if err != nil {
+		result.Status = StatusFail
+	}
+	if check.Details != nil {
+		result.Details = check.Details()
+	}
+	return result
+}
@@ -0,0 +1,241 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tictactoe"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	rateLimited     *prometheus.CounterVec
+
+	rpcs        *prometheus.CounterVec
+	rpcDuration *prometheus.HistogramVec
+
+	gamesCreated  *prometheus.CounterVec
+	gamesFinished *prometheus.CounterVec
+
+	searchDuration *prometheus.HistogramVec
+	searchNodes    *prometheus.HistogramVec
+	searchTimeouts *prometheus.CounterVec
+	queueWait      prometheus.Histogram
+	rejected       prometheus.Counter
+
+	repositoryDuration *prometheus.HistogramVec
+
+	eventsDropped *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method and route pattern.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "rate_limited_total",
+			Help:      "Requests rejected with 429 by rate limit.",
+		}, []string{"limit"}),
+
+		rpcs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "gRPC calls by full method name and status code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "gRPC call latency by full method name.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+
+		gamesCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "games_created_total",
+			Help:      "Games created by mode and board size.",
+		}, []string{"mode", "size"}),
+		gamesFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "games_finished_total",
+			Help:      "Games finished by outcome, mode and board size.",
+		}, []string{"outcome", "mode", "size"}),
+
+		searchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "engine",
+			Name:      "search_duration_seconds",
+			Help:      "Minimax search duration by board size.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 4, 9),
+		}, []string{"size"}),
+		searchNodes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "engine",
+			Name:      "search_nodes",
+			Help:      "Positions visited by a minimax search by board size.",
+			Buckets:   prometheus.ExponentialBuckets(10, 10, 8),
+		}, []string{"size"}),
+		searchTimeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "engine",
+			Name:      "search_timeouts_total",
+			Help:      "Minimax searches interrupted before completion by board size.",
+		}, []string{"size"}),
+
+		queueWait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "engine",
+			Name:      "queue_wait_seconds",
+			Help:      "Time a search waited in the queue for a free worker.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 4, 9),
+		}),
+		rejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "engine",
+			Name:      "rejected_total",
+			Help:      "Searches rejected because the queue was full.",
+		}),
+
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "operation_duration_seconds",
+			Help:      "Repository operation latency by repository and operation.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 10),
+		}, []string{"repository", "operation"}),
+
+		eventsDropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "events",
+			Name:      "dropped_total",
+			Help:      "Domain events a subscriber never received, by subscriber and event type.",
+		}, []string{"subscriber", "event"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.rateLimited,
+		m.rpcs,
+		m.rpcDuration,
+		m.gamesCreated,
+		m.gamesFinished,
+		m.searchDuration,
+		m.searchNodes,
+		m.searchTimeouts,
+		m.queueWait,
+		m.rejected,
+		m.repositoryDuration,
+		m.eventsDropped,
+	)
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) RegisterActiveGames(count func() int) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_games",
+		Help:      "Games in progress in storage.",
+	}, func() float64 {
+		return float64(count())
+	}))
+}
+
+func (m *Metrics) RegisterEngineQueue(queued, running func() int) {
+	m.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "engine",
+			Name:      "queued_searches",
+			Help:      "Searches waiting for a free worker.",
+		}, func() float64 {
+			return float64(queued())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "engine",
+			Name:      "running_searches",
+			Help:      "Searches currently running on workers.",
+		}, func() float64 {
+			return float64(running())
+		}),
+	)
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	m.requests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.requestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveRPC(method, code string, duration time.Duration) {
+	m.rpcs.WithLabelValues(method, code).Inc()
+	m.rpcDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) GameCreated(mode string, size int) {
+	m.gamesCreated.WithLabelValues(mode, strconv.Itoa(size)).Inc()
+}
+
+func (m *Metrics) GameFinished(outcome, mode string, size int) {
+	m.gamesFinished.WithLabelValues(outcome, mode, strconv.Itoa(size)).Inc()
+}
+
+func (m *Metrics) ObserveSearch(size, nodes int, duration time.Duration, interrupted bool) {
+	label := strconv.Itoa(size)
+	m.searchDuration.WithLabelValues(label).Observe(duration.Seconds())
+	m.searchNodes.WithLabelValues(label).Observe(float64(nodes))
+	if interrupted {
+		m.searchTimeouts.WithLabelValues(label).Inc()
+	}
+}
+
+func (m *Metrics) ObserveEngineQueueWait(wait time.Duration) {
+	m.queueWait.Observe(wait.Seconds())
+}
+
+func (m *Metrics) RateLimited(limit string) {
+	m.rateLimited.WithLabelValues(limit).Inc()
+}
+
+func (m *Metrics) EngineRejected() {
+	m.rejected.Inc()
+}
+
+func (m *Metrics) ObserveRepository(repository, operation string, started time.Time) {
+	m.repositoryDuration.WithLabelValues(repository, operation).Observe(time.Since(started).Seconds())
+}
+
+func (m *Metrics) EventDropped(subscriber, eventType string) {
+	m.eventsDropped.WithLabelValues(subscriber, eventType).Inc()
+}
@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/service"
+	"tictactoe/internal/metrics"
+)
+
+// Guard is checked by REST, WebSocket and gRPC before they create a game,
+// apply a move or render an image, so a client gets the same buckets whatever
+// transport it uses. A rejected call returns a *service.RetryError wrapping
+// service.ErrRateLimited.
+type Guard interface {
+	Key(client Client) string
+	RequestKey(r *http.Request) string
+	AllowCreate(key string) error
+	AllowMove(key string, gameID uuid.UUID) error
+	AllowRender(key string) error
+}
+
+type Options struct {
+	Key            string
+	APIKeys        []string
+	TrustedProxies []string
+	MaxClients     int
+	Create         Limit
+	Move           Limit
+	Render         Limit
+}
+
+// Limits is the Guard for the configured limits. A limit with no requests per
+// minute is off.
+type Limits struct {
+	*Keys
+
+	create  *Limiter
+	move    *Limiter
+	render  *Limiter
+	metrics *metrics.Metrics
+}
+
+func NewLimits(options Options, m *metrics.Metrics) *Limits {
+	return &Limits{
+		Keys:    NewKeys(options.Key, options.TrustedProxies, options.APIKeys),
+		create:  newLimiter(options.Create, options.MaxClients),
+		move:    newLimiter(options.Move, options.MaxClients),
+		render:  newLimiter(options.Render, options.MaxClients),
+		metrics: m,
+	}
+}
+
+func newLimiter(limit Limit, maxClients int) *Limiter {
+	if limit.PerMinute <= 0 {
+		return nil
+	}
+	return NewLimiter(limit, maxClients)
+}
+
+func (l *Limits) AllowCreate(key string) error {
+	return l.allow("create", l.create, key)
+}
+
+// AllowMove gives the client a separate bucket for every game.
+func (l *Limits) AllowMove(key string, gameID uuid.UUID) error {
+	return l.allow("move", l.move, key+" game:"+gameID.String())
+}
+
+func (l *Limits) AllowRender(key string) error {
+	return l.allow("render", l.render, key)
+}
+
+func (l *Limits) allow(name string, limiter *Limiter, key string) error {
+	if limiter == nil {
+		return nil
+	}
+
+	allowed, wait := limiter.Allow(key)
+	if allowed {
+		return nil
+	}
+
+	l.metrics.RateLimited(name)
+	return &service.RetryError{
+		Err:        fmt.Errorf("%w: try again in %s", service.ErrRateLimited, wait.Round(time.Second)),
+		RetryAfter: wait,
+	}
+}
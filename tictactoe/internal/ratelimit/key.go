@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/web/auth"
+)
+
+const (
+	ByIP     = "ip"
+	ByUser   = "user"
+	ByAPIKey = "api_key"
+
+	APIKeyHeader = "X-API-Key"
+)
+
+// Client is what a transport knows about the caller.
+type Client struct {
+	APIKey string
+	UserID uuid.UUID
+	IP     string
+}
+
+// Keys builds client keys for the configured key source. Only the configured
+// API keys are trusted: a client with an unknown or missing key is limited by
+// its user, and a client without a user by its IP.
+type Keys struct {
+	source  string
+	proxies []netip.Prefix
+	apiKeys map[[sha256.Size]byte]int
+}
+
+// NewKeys skips trusted proxies that are neither an IP address nor a CIDR;
+// the config validation reports them.
+func NewKeys(source string, trustedProxies, apiKeys []string) *Keys {
+	known := make(map[[sha256.Size]byte]int, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		known[sha256.Sum256([]byte(apiKey))] = i
+	}
+
+	var proxies []netip.Prefix
+	for _, proxy := range trustedProxies {
+		if prefix, err := netip.ParsePrefix(proxy); err == nil {
+			proxies = append(proxies, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(proxy); err == nil {
+			proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+
+	return &Keys{
+		source:  source,
+		proxies: proxies,
+		apiKeys: known,
+	}
+}
+
+func (k *Keys) Key(client Client) string {
+	if k.source == ByAPIKey && client.APIKey != "" {
+		if index, ok := k.apiKeys[sha256.Sum256([]byte(client.APIKey))]; ok {
+			return "key:" + strconv.Itoa(index)
+		}
+	}
+	if k.source != ByIP && client.UserID != uuid.Nil {
+		return "user:" + client.UserID.String()
+	}
+	return "ip:" + client.IP
+}
+
+// RequestKey must run after AuthMiddleware when clients are keyed by user.
+func (k *Keys) RequestKey(r *http.Request) string {
+	userID, _ := auth.UserIDFromContext(r.Context())
+	return k.Key(Client{
+		APIKey: r.Header.Get(APIKeyHeader),
+		UserID: userID,
+		IP:     k.clientIP(r),
+	})
+}
+
+// clientIP walks X-Forwarded-For from the right while the hop that reported
+// the address is a trusted proxy. The leftmost entries are written by the
+// client itself, so the first untrusted address from the right is the only
+// one a client cannot forge.
+func (k *Keys) clientIP(r *http.Request) string {
+	ip := HostOf(r.RemoteAddr)
+	if !k.trusted(ip) {
+		return ip
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		ip = hop
+		if !k.trusted(ip) {
+			break
+		}
+	}
+	return ip
+}
+
+func (k *Keys) trusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, proxy := range k.proxies {
+		if proxy.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// HostOf strips the port from a network address.
+func HostOf(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
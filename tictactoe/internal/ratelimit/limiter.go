@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+type bucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+// Limiter keeps a token bucket per client. Only the maxClients most recently
+// seen clients are tracked; a forgotten client starts with a full bucket again.
+type Limiter struct {
+	rate       float64
+	burst      float64
+	maxClients int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	buckets map[string]*list.Element
+}
+
+func NewLimiter(limit Limit, maxClients int) *Limiter {
+	return &Limiter{
+		rate:       float64(limit.PerMinute) / 60,
+		burst:      float64(limit.Burst),
+		maxClients: maxClients,
+		now:        time.Now,
+		order:      list.New(),
+		buckets:    make(map[string]*list.Element),
+	}
+}
+
+// Allow takes a token from the client's bucket. When the bucket is empty it
+// returns how long the client has to wait for the next token.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(key, now)
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+func (l *Limiter) bucket(key string, now time.Time) *bucket {
+	if element, ok := l.buckets[key]; ok {
+		l.order.MoveToFront(element)
+		return element.Value.(*bucket)
+	}
+
+	if l.order.Len() >= l.maxClients {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.buckets, oldest.Value.(*bucket).key)
+	}
+
+	b := &bucket{key: key, tokens: l.burst, last: now}
+	l.buckets[key] = l.order.PushFront(b)
+	return b
+}
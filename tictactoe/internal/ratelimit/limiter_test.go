@@ -0,0 +1,203 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/service"
+	"tictactoe/internal/metrics"
+)
+
+// testClock is the time seen by limiters under test; it only moves when the
+// test advances it.
+type testClock struct{ now time.Time }
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func newTestLimiter(limit Limit, maxClients int, clock *testClock) *Limiter {
+	limiter := NewLimiter(limit, maxClients)
+	limiter.now = clock.Now
+	return limiter
+}
+
+func TestLimiterAllow(t *testing.T) {
+	type step struct {
+		key     string
+		advance time.Duration
+		allowed bool
+		wait    time.Duration
+	}
+
+	tests := []struct {
+		name       string
+		limit      Limit
+		maxClients int
+		steps      []step
+	}{
+		{
+			name:       "burst then refill",
+			limit:      Limit{PerMinute: 60, Burst: 2},
+			maxClients: 10,
+			steps: []step{
+				{key: "a", allowed: true},
+				{key: "a", allowed: true},
+				{key: "a", wait: time.Second},
+				{key: "a", advance: 500 * time.Millisecond, wait: 500 * time.Millisecond},
+				{key: "a", advance: 500 * time.Millisecond, allowed: true},
+			},
+		},
+		{
+			name:       "refill stops at burst",
+			limit:      Limit{PerMinute: 60, Burst: 2},
+			maxClients: 10,
+			steps: []step{
+				{key: "a", allowed: true},
+				{key: "a", allowed: true},
+				{key: "a", advance: time.Hour, allowed: true},
+				{key: "a", allowed: true},
+				{key: "a", wait: time.Second},
+			},
+		},
+		{
+			name:       "slow rate",
+			limit:      Limit{PerMinute: 30, Burst: 1},
+			maxClients: 10,
+			steps: []step{
+				{key: "a", allowed: true},
+				{key: "a", wait: 2 * time.Second},
+				{key: "a", advance: time.Second, wait: time.Second},
+				{key: "a", advance: time.Second, allowed: true},
+			},
+		},
+		{
+			name:       "separate clients",
+			limit:      Limit{PerMinute: 60, Burst: 1},
+			maxClients: 10,
+			steps: []step{
+				{key: "a", allowed: true},
+				{key: "b", allowed: true},
+				{key: "a", wait: time.Second},
+				{key: "b", wait: time.Second},
+			},
+		},
+		{
+			name:       "least recently seen client is forgotten",
+			limit:      Limit{PerMinute: 60, Burst: 1},
+			maxClients: 2,
+			steps: []step{
+				{key: "a", allowed: true},
+				{key: "b", allowed: true},
+				{key: "a", wait: time.Second},
+				{key: "c", allowed: true},
+				{key: "a", wait: time.Second},
+				{key: "b", allowed: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			limiter := newTestLimiter(tt.limit, tt.maxClients, clock)
+
+			for i, step := range tt.steps {
+				clock.now = clock.now.Add(step.advance)
+
+				allowed, wait := limiter.Allow(step.key)
+				if allowed != step.allowed || wait != step.wait {
+					t.Errorf("step %d: Allow(%q) = %t, %s, want %t, %s", i, step.key, allowed, wait, step.allowed, step.wait)
+				}
+			}
+		})
+	}
+}
+
+func TestLimitsAllow(t *testing.T) {
+	gameID := uuid.New()
+
+	tests := []struct {
+		name  string
+		limit Limit
+		allow func(l *Limits) error
+		wait  time.Duration
+	}{
+		{
+			name:  "create",
+			limit: Limit{PerMinute: 6, Burst: 1},
+			allow: func(l *Limits) error { return l.AllowCreate("ip:192.0.2.1") },
+			wait:  10 * time.Second,
+		},
+		{
+			name:  "move",
+			limit: Limit{PerMinute: 60, Burst: 1},
+			allow: func(l *Limits) error { return l.AllowMove("ip:192.0.2.1", gameID) },
+			wait:  time.Second,
+		},
+		{
+			name:  "render",
+			limit: Limit{PerMinute: 1, Burst: 1},
+			allow: func(l *Limits) error { return l.AllowRender("ip:192.0.2.1") },
+			wait:  time.Minute,
+		},
+		{
+			name:  "off",
+			limit: Limit{},
+			allow: func(l *Limits) error { return l.AllowCreate("ip:192.0.2.1") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			limits := NewLimits(Options{
+				Key:        ByIP,
+				MaxClients: 10,
+				Create:     tt.limit,
+				Move:       tt.limit,
+				Render:     tt.limit,
+			}, metrics.New())
+			for _, limiter := range []*Limiter{limits.create, limits.move, limits.render} {
+				if limiter != nil {
+					limiter.now = clock.Now
+				}
+			}
+
+			if err := tt.allow(limits); err != nil {
+				t.Fatalf("first call = %v, want it allowed", err)
+			}
+
+			err := tt.allow(limits)
+			if tt.wait == 0 {
+				if err != nil {
+					t.Errorf("second call = %v, want no limit", err)
+				}
+				return
+			}
+
+			var retry *service.RetryError
+			if !errors.As(err, &retry) || !errors.Is(err, service.ErrRateLimited) {
+				t.Fatalf("second call = %v, want a retry error wrapping %v", err, service.ErrRateLimited)
+			}
+			if retry.RetryAfter != tt.wait {
+				t.Errorf("RetryAfter = %s, want %s", retry.RetryAfter, tt.wait)
+			}
+		})
+	}
+}
+
+func TestAllowMoveKeepsGamesApart(t *testing.T) {
+	limits := NewLimits(Options{Key: ByIP, MaxClients: 10, Move: Limit{PerMinute: 60, Burst: 1}}, metrics.New())
+
+	if err := limits.AllowMove("ip:192.0.2.1", uuid.New()); err != nil {
+		t.Fatal(err)
+	}
+	if err := limits.AllowMove("ip:192.0.2.1", uuid.New()); err != nil {
+		t.Errorf("move in another game = %v, want it allowed", err)
+	}
+}
@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"tictactoe/internal/logging"
+	"tictactoe/internal/metrics"
+)
+
+// UnaryRecoveryInterceptor answers a panicking call with codes.Internal
+// instead of letting the panic take the whole server down.
+func UnaryRecoveryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = panicked(ctx, logger, info.FullMethod, recovered)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func StreamRecoveryInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = panicked(stream.Context(), logger, info.FullMethod, recovered)
+			}
+		}()
+		return handler(srv, stream)
+	}
+}
+
+func panicked(ctx context.Context, logger *zap.Logger, method string, recovered any) error {
+	logging.FromContext(ctx, logger).Error("handler panicked",
+		zap.String("method", method),
+		zap.Any("panic", recovered),
+		zap.StackSkip("stack", 3))
+	return status.Error(codes.Internal, "internal server error")
+}
+
+func UnaryLoggingInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, logger, info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
+
+func StreamLoggingInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		logCall(stream.Context(), logger, info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+// logCall logs at the same levels as the HTTP access log: server errors as
+// errors and client errors as warnings.
+func logCall(ctx context.Context, logger *zap.Logger, method string, err error, duration time.Duration) {
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("code", code.String()),
+		zap.Duration("duration", duration),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.Stringer("remote", p.Addr))
+	}
+	if err != nil {
+		fields = append(fields, zap.String("error", status.Convert(err).Message()))
+	}
+
+	callLogger := logging.FromContext(ctx, logger)
+	switch code {
+	case codes.OK, codes.Canceled:
+		callLogger.Info("call", fields...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		callLogger.Error("call", fields...)
+	default:
+		callLogger.Warn("call", fields...)
+	}
+}
+
+func UnaryMetricsInterceptor(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.ObserveRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+func StreamMetricsInterceptor(m *metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		m.ObserveRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/notify"
+	"tictactoe/internal/rpc/gamepb"
+)
+
+func toGame(game *model.Game) *gamepb.Game {
+	if game == nil {
+		return nil
+	}
+
+	field := make([]*gamepb.Row, len(game.Field))
+	for i, row := range game.Field {
+		cells := make([]int32, len(row))
+		for j, cell := range row {
+			cells[j] = int32(cell)
+		}
+		field[i] = &gamepb.Row{Cells: cells}
+	}
+
+	return &gamepb.Game{
+		Id:            game.ID.String(),
+		Mode:          game.Mode,
+		Size:          int32(game.Size),
+		Field:         field,
+		Status:        string(game.State),
+		PlayerTurn:    int32(game.PlayerTurn),
+		OwnerId:       optionalUUID(game.OwnerID),
+		OpponentId:    optionalUUID(game.OpponentID),
+		DrawOfferedBy: optionalUUID(game.DrawOfferedBy),
+		CreatedAt:     timestamppb.New(game.CreatedAt),
+		UpdatedAt:     timestamppb.New(game.UpdatedAt),
+	}
+}
+
+func toGameUpdate(update notify.Update) *gamepb.GameUpdate {
+	response := &gamepb.GameUpdate{
+		Seq:     update.Seq,
+		Type:    update.Type,
+		Game:    toGame(update.Game),
+		UserId:  optionalUUID(update.UserID),
+		Message: update.Message,
+		Time:    timestamppb.New(update.Time),
+	}
+	if update.Move != nil {
+		response.Move = &gamepb.Move{
+			Row:    int32(update.Move.Row),
+			Col:    int32(update.Move.Col),
+			Player: int32(update.Move.Player),
+		}
+	}
+	return response
+}
+
+func optionalUUID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,220 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/notify"
+	"tictactoe/internal/domain/service"
+	"tictactoe/internal/ratelimit"
+	"tictactoe/internal/rpc/gamepb"
+	"tictactoe/internal/web/auth"
+)
+
+type GameServer struct {
+	gamepb.UnimplementedGameServiceServer
+
+	gameService service.GameService
+	hub         *notify.Hub
+	limits      ratelimit.Guard
+}
+
+func NewGameServer(gameService service.GameService, hub *notify.Hub, limits ratelimit.Guard) *GameServer {
+	return &GameServer{
+		gameService: gameService,
+		hub:         hub,
+		limits:      limits,
+	}
+}
+
+func (s *GameServer) CreateGame(ctx context.Context, req *gamepb.CreateGameRequest) (*gamepb.Game, error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.limits.AllowCreate(s.clientKey(ctx, userID)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	size := int(req.GetSize())
+	if size == 0 {
+		size = model.FieldSize
+	}
+
+	game, err := s.gameService.CreateGame(ctx, model.GameOptions{
+		Size:    size,
+		OwnerID: userID,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toGame(game), nil
+}
+
+func (s *GameServer) GetGame(ctx context.Context, req *gamepb.GetGameRequest) (*gamepb.Game, error) {
+	_, game, err := s.getOwnedGame(ctx, req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+
+	return toGame(game), nil
+}
+
+func (s *GameServer) MakeMove(ctx context.Context, req *gamepb.MakeMoveRequest) (*gamepb.Game, error) {
+	userID, game, err := s.getOwnedGame(ctx, req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.limits.AllowMove(s.clientKey(ctx, userID), game.ID); err != nil {
+		return nil, toStatus(err)
+	}
+
+	game, err = s.gameService.PlayMove(ctx, game.ID, userID, int(req.GetRow()), int(req.GetCol()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toGame(game), nil
+}
+
+func (s *GameServer) WatchGame(req *gamepb.WatchGameRequest, stream gamepb.GameService_WatchGameServer) error {
+	ctx := stream.Context()
+
+	_, game, err := s.getOwnedGame(ctx, req.GetGameId())
+	if err != nil {
+		return err
+	}
+
+	subscription := s.hub.Subscribe(notify.GameTopic(game.ID), req.GetLastSeq())
+	defer subscription.Close()
+
+	// Read the snapshot again after subscribing, so no update falls between them.
+	game, err = s.gameService.GetGame(ctx, game.ID)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	if subscription.Resumed {
+		for _, update := range subscription.Missed {
+			if err := stream.Send(toGameUpdate(update)); err != nil {
+				return err
+			}
+		}
+	} else {
+		snapshot := notify.Update{
+			Seq:    subscription.Seq,
+			Type:   notify.UpdateSnapshot,
+			GameID: game.ID,
+			Game:   game,
+			Time:   time.Now(),
+		}
+		if err := stream.Send(toGameUpdate(snapshot)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case update, ok := <-subscription.Updates:
+			if !ok {
+				if s.hub.Closed() {
+					return status.Error(codes.Unavailable, service.ErrShuttingDown.Error())
+				}
+				return status.Error(codes.ResourceExhausted, "subscriber is too slow")
+			}
+			if err := stream.Send(toGameUpdate(update)); err != nil {
+				return err
+			}
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *GameServer) getOwnedGame(ctx context.Context, id string) (uuid.UUID, *model.Game, error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	gameID, err := parseGameID(id)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	game, err := s.gameService.GetGame(ctx, gameID)
+	if err != nil {
+		return uuid.Nil, nil, toStatus(err)
+	}
+
+	if !game.IsParticipant(userID) {
+		return uuid.Nil, nil, toStatus(service.ErrAccessDenied)
+	}
+
+	return userID, game, nil
+}
+
+// clientKey identifies the caller to the rate limits the same way REST does:
+// the API key travels in x-api-key metadata and the IP is the peer address.
+func (s *GameServer) clientKey(ctx context.Context, userID uuid.UUID) string {
+	client := ratelimit.Client{UserID: userID}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(strings.ToLower(ratelimit.APIKeyHeader)); len(values) > 0 {
+			client.APIKey = values[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		client.IP = ratelimit.HostOf(p.Addr.String())
+	}
+	return s.limits.Key(client)
+}
+
+func requireUser(ctx context.Context) (uuid.UUID, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return userID, nil
+}
+
+func parseGameID(id string) (uuid.UUID, error) {
+	gameID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid game UUID: %v", err))
+	}
+	return gameID, nil
+}
+
+func toStatus(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, model.ErrGameNotFound), errors.Is(err, model.ErrUserNotFound):
+		code = codes.NotFound
+	case errors.Is(err, service.ErrAccessDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, service.ErrInvalidInput), errors.Is(err, model.ErrInvalidMove):
+		code = codes.InvalidArgument
+	case errors.Is(err, model.ErrCellOccupied), errors.Is(err, service.ErrGameFinished), errors.Is(err, service.ErrNotYourTurn):
+		code = codes.FailedPrecondition
+	case errors.Is(err, model.ErrConflict):
+		code = codes.Aborted
+	case errors.Is(err, service.ErrShuttingDown), errors.Is(err, service.ErrEngineSaturated):
+		code = codes.Unavailable
+	case errors.Is(err, model.ErrStorageFull), errors.Is(err, service.ErrRateLimited):
+		code = codes.ResourceExhausted
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,14 @@
+package tracing
+
+import "go.opentelemetry.io/otel/attribute"
+
+const (
+	GameIDKey    = attribute.Key("game.id")
+	UserIDKey    = attribute.Key("user.id")
+	BoardSizeKey = attribute.Key("game.board_size")
+	RequestIDKey = attribute.Key("request.id")
+
+	SearchDepthKey     = attribute.Key("engine.depth")
+	SearchNodesKey     = attribute.Key("engine.nodes")
+	SearchCancelledKey = attribute.Key("engine.cancelled")
+)
@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var idFields = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+// fileClient writes every export batch as one line of OTLP JSON, the format
+// read by the collector's otlpjsonfile receiver.
+type fileClient struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileClient(path string) *fileClient {
+	return &fileClient{path: path}
+}
+
+func (c *fileClient) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	file, err := os.OpenFile(c.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+
+	c.file = file
+	return nil
+}
+
+func (c *fileClient) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return nil
+	}
+
+	err := c.file.Close()
+	c.file = nil
+	return err
+}
+
+func (c *fileClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	data, err := encodeOTLP(&coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans})
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return fmt.Errorf("trace file is closed")
+	}
+
+	if _, err := c.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write spans: %w", err)
+	}
+
+	return nil
+}
+
+// encodeOTLP differs from plain protojson as the OTLP JSON encoding requires:
+// enums are numbers and trace and span IDs are hex instead of base64.
+func encodeOTLP(request *coltracepb.ExportTraceServiceRequest) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if err := hexIDs(doc); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+func hexIDs(node any) error {
+	switch value := node.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if encoded, ok := child.(string); ok && idFields[key] {
+				id, err := base64.StdEncoding.DecodeString(encoded)
+				if err != nil {
+					return fmt.Errorf("invalid %s: %w", key, err)
+				}
+				value[key] = hex.EncodeToString(id)
+				continue
+			}
+			if err := hexIDs(child); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, child := range value {
+			if err := hexIDs(child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type userIDKey struct{}
+
+func WithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(uuid.UUID)
+	return userID, ok
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/model"
+)
+
+const (
+	issuer = "tictactoe"
+
+	// streamAudience marks tokens that only open one event stream. They travel
+	// in URLs, where browsers cannot set headers, so they are short-lived and
+	// are not accepted as API tokens.
+	streamAudience = "stream"
+	StreamTokenTTL = time.Minute
+
+	LobbyStream = "lobby"
+)
+
+type JwtProvider struct {
+	signingKey []byte
+	tokenTTL   time.Duration
+}
+
+type claims struct {
+	Login  string `json:"login,omitempty"`
+	Stream string `json:"stream,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func NewJwtProvider(signingKey string, tokenTTL time.Duration) *JwtProvider {
+	return &JwtProvider{
+		signingKey: []byte(signingKey),
+		tokenTTL:   tokenTTL,
+	}
+}
+
+func (p *JwtProvider) GenerateToken(user *model.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(p.tokenTTL)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Login: user.Login,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   user.ID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(p.signingKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+// GameStream names the event streams of one game for GenerateStreamToken.
+func GameStream(gameID uuid.UUID) string {
+	return "game:" + gameID.String()
+}
+
+// GenerateStreamToken issues a token that opens only the given stream.
+func (p *JwtProvider) GenerateStreamToken(userID uuid.UUID, stream string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(StreamTokenTTL)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Stream: stream,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   userID.String(),
+			Audience:  jwt.ClaimStrings{streamAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(p.signingKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+func (p *JwtProvider) ParseToken(tokenString string) (uuid.UUID, error) {
+	parsed, err := p.parse(tokenString)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(parsed.Audience) > 0 {
+		return uuid.Nil, fmt.Errorf("invalid token: stream tokens only open event streams")
+	}
+
+	return subject(parsed)
+}
+
+// ParseStreamToken accepts only a stream token issued for stream.
+func (p *JwtProvider) ParseStreamToken(tokenString, stream string) (uuid.UUID, error) {
+	parsed, err := p.parse(tokenString, jwt.WithAudience(streamAudience))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if parsed.Stream != stream {
+		return uuid.Nil, fmt.Errorf("invalid token: issued for another stream")
+	}
+
+	return subject(parsed)
+}
+
+func (p *JwtProvider) parse(tokenString string, options ...jwt.ParserOption) (*claims, error) {
+	var parsed claims
+
+	options = append(options,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	_, err := jwt.ParseWithClaims(tokenString, &parsed, func(token *jwt.Token) (any, error) {
+		return p.signingKey, nil
+	}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	return &parsed, nil
+}
+
+func subject(parsed *claims) (uuid.UUID, error) {
+	userID, err := uuid.Parse(parsed.Subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid token subject")
+	}
+
+	return userID, nil
+}
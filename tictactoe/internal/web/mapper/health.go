@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"tictactoe/internal/health"
+	webModel "tictactoe/internal/web/model"
+)
+
+func ToHealthResponse(report health.Report) webModel.HealthResponse {
+	response := webModel.HealthResponse{
+		Status: report.Status,
+		Checks: make(map[string]webModel.CheckResponse, len(report.Results)),
+	}
+
+	for _, result := range report.Results {
+		check := webModel.CheckResponse{
+			Status:     result.Status,
+			Details:    result.Details,
+			DurationMs: float64(result.Duration.Microseconds()) / 1000,
+		}
+		if result.Error != nil {
+			check.Error = result.Error.Error()
+		}
+		response.Checks[result.Name] = check
+	}
+
+	return response
+}
@@ -3,8 +3,13 @@ package mapper
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
+	"github.com/google/uuid"
 	domainModel "tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/notify"
+	"tictactoe/internal/web/render"
 	webModel "tictactoe/internal/web/model"
 )
 
@@ -14,14 +19,166 @@ func ToMoveResponse(game *domainModel.Game) *webModel.MoveResponse {
 	}
 	return &webModel.MoveResponse{
 		GameID: game.ID.String(),
+		Mode:   game.Mode,
+		Size:   game.Size,
 		Field:  game.Field,
 		Status: string(game.State),
+		PlayerTurn: game.PlayerTurn,
+		DrawOfferedBy: optionalUUID(game.DrawOfferedBy),
+		AIJobID: optionalUUID(game.AIJobID),
+		Moves:  toMoveInfos(game.Moves),
+		WinningLine: toMoveInfos(game.WinningLine()),
 	}
 }
 
-func ToErrorResponse(err error) *webModel.ErrorResponse {
-	return &webModel.ErrorResponse{
+func toMoveInfos(moves []domainModel.Move) []webModel.MoveInfo {
+	infos := make([]webModel.MoveInfo, len(moves))
+	for i, move := range moves {
+		infos[i] = webModel.MoveInfo{
+			Row:    move.Row,
+			Col:    move.Col,
+			Player: move.Player,
+		}
+	}
+	return infos
+}
+
+func ToAIJobResponse(job *domainModel.AIJob) *webModel.AIJobResponse {
+	response := &webModel.AIJobResponse{
+		JobID:     job.ID.String(),
+		GameID:    job.GameID.String(),
+		Status:    job.Status,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt.Format(time.RFC3339Nano),
+	}
+	if job.Move != nil {
+		response.Move = &webModel.MoveInfo{
+			Row:    job.Move.Row,
+			Col:    job.Move.Col,
+			Player: job.Move.Player,
+		}
+	}
+	if job.IsFinished() {
+		response.FinishedAt = job.FinishedAt.Format(time.RFC3339Nano)
+	}
+	return response
+}
+
+func ToAcceptedMoveResponse(game *domainModel.Game, job *domainModel.AIJob) *webModel.AcceptedMoveResponse {
+	return &webModel.AcceptedMoveResponse{
+		Game: ToMoveResponse(game),
+		Job:  ToAIJobResponse(job),
+	}
+}
+
+func ToGameUpdateResponse(update notify.Update) *webModel.GameUpdateResponse {
+	response := &webModel.GameUpdateResponse{
+		Type:    update.Type,
+		Seq:     update.Seq,
+		Mode:    update.Mode,
+		Status:  update.Status,
+		Game:    ToMoveResponse(update.Game),
+		UserID:  optionalUUID(update.UserID),
+		Message: update.Message,
+		Size:    update.Size,
+		Waiting: update.Waiting,
+		Time:    update.Time.Format(time.RFC3339Nano),
+	}
+	if update.Game == nil {
+		response.GameID = optionalUUID(update.GameID)
+	}
+	if update.Move != nil {
+		response.Move = &webModel.MoveInfo{
+			Row:    update.Move.Row,
+			Col:    update.Move.Col,
+			Player: update.Move.Player,
+		}
+	}
+	return response
+}
+
+func ToSocketError(err error) *webModel.GameUpdateResponse {
+	return &webModel.GameUpdateResponse{
+		Type:  "error",
 		Error: err.Error(),
+		Code:  ToProblemResponse(http.StatusBadRequest, err).Code,
+		Time:  time.Now().Format(time.RFC3339Nano),
+	}
+}
+
+func optionalUUID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+func ToCreateGameResponse(game *domainModel.Game) *webModel.CreateGameResponse {
+	response := &webModel.CreateGameResponse{
+		GameID: game.ID.String(),
+		Field:  game.Field,
+		Status: string(game.State),
+	}
+	for _, webhook := range game.Webhooks {
+		response.Webhooks = append(response.Webhooks, webModel.WebhookResponse{
+			URL:    webhook.URL,
+			Events: webhook.Events,
+			Secret: webhook.Secret,
+		})
+	}
+	return response
+}
+
+func WebhooksFromRequest(webhooks []webModel.WebhookRequest) []domainModel.Webhook {
+	result := make([]domainModel.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, domainModel.Webhook{
+			URL:    webhook.URL,
+			Events: webhook.Events,
+			Secret: webhook.Secret,
+		})
+	}
+	return result
+}
+
+func ToMatchResponse(match *domainModel.Match) *webModel.MatchResponse {
+	response := &webModel.MatchResponse{
+		Game:     ToMoveResponse(match.Game),
+		Opponent: domainModel.ModeAI,
+		Player:   match.Player,
+	}
+	if match.OpponentID != uuid.Nil {
+		response.OpponentID = match.OpponentID.String()
+		response.Opponent = "user"
+	}
+	return response
+}
+
+func ToGameListResponse(games []*domainModel.Game) *webModel.GameListResponse {
+	response := &webModel.GameListResponse{
+		Games: make([]*webModel.MoveResponse, 0, len(games)),
+	}
+	for _, game := range games {
+		response.Games = append(response.Games, ToMoveResponse(game))
+	}
+	return response
+}
+
+func ToStatsResponse(stats *domainModel.UserStats) *webModel.StatsResponse {
+	return &webModel.StatsResponse{
+		Total:      stats.Total,
+		Won:        stats.Won,
+		Lost:       stats.Lost,
+		Draw:       stats.Draw,
+		InProgress: stats.InProgress,
+	}
+}
+
+func ToUserResponse(user *domainModel.User) *webModel.UserResponse {
+	return &webModel.UserResponse{
+		UserID: user.ID.String(),
+		Login:  user.Login,
+		Rating: user.Rating,
 	}
 }
 
@@ -71,4 +228,17 @@ func WriteJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	if data != nil {
 		json.NewEncoder(w).Encode(data)
 	}
-}
\ No newline at end of file
+}
+
+func WriteGame(w http.ResponseWriter, r *http.Request, statusCode int, game *domainModel.Game) {
+	w.Header().Add("Vary", "Accept")
+	
+	if render.Format(r) == render.FormatASCII {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(statusCode)
+		io.WriteString(w, render.ASCII(game))
+		return
+	}
+	
+	WriteJSON(w, statusCode, ToMoveResponse(game))
+}
@@ -0,0 +1,99 @@
+package mapper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	domainModel "tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/service"
+	webModel "tictactoe/internal/web/model"
+)
+
+const problemTypePrefix = "urn:tictactoe:problem:"
+
+var (
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request body")
+	ErrIdempotencyKeyInUse  = errors.New("a request with this idempotency key is still being processed")
+)
+
+type problem struct {
+	err    error
+	status int
+	code   string
+	title  string
+}
+
+var problems = []problem{
+	{domainModel.ErrGameNotFound, http.StatusNotFound, "game-not-found", "Game not found"},
+	{domainModel.ErrUserNotFound, http.StatusNotFound, "user-not-found", "User not found"},
+	{service.ErrJobNotFound, http.StatusNotFound, "job-not-found", "AI job not found"},
+	{domainModel.ErrLoginTaken, http.StatusConflict, "login-taken", "Login is already taken"},
+	{domainModel.ErrCellOccupied, http.StatusConflict, "cell-occupied", "Cell is already occupied"},
+	{domainModel.ErrInvalidMove, http.StatusUnprocessableEntity, "invalid-move", "Invalid move"},
+	{domainModel.ErrConflict, http.StatusConflict, "conflict", "Conflict with the current game state"},
+	{domainModel.ErrStorageFull, http.StatusServiceUnavailable, "storage-full", "Game storage is full"},
+	{service.ErrGameFinished, http.StatusConflict, "game-finished", "Game is already finished"},
+	{service.ErrNotYourTurn, http.StatusConflict, "not-your-turn", "It is not your turn"},
+	{service.ErrAccessDenied, http.StatusForbidden, "access-denied", "Access denied"},
+	{service.ErrInvalidInput, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{service.ErrInvalidCredentials, http.StatusUnauthorized, "invalid-credentials", "Invalid login or password"},
+	{service.ErrAlreadyQueued, http.StatusConflict, "already-queued", "Already waiting in matchmaking queue"},
+	{service.ErrShuttingDown, http.StatusServiceUnavailable, "shutting-down", "Server is shutting down"},
+	{service.ErrRateLimited, http.StatusTooManyRequests, "rate-limited", "Too many requests"},
+	{ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, "idempotency-key-reused", "Idempotency key reused with a different request"},
+	{ErrIdempotencyKeyInUse, http.StatusConflict, "idempotency-key-in-use", "Request with this idempotency key is in progress"},
+	{service.ErrEngineSaturated, http.StatusServiceUnavailable, "engine-saturated", "Engine is saturated"},
+}
+
+func ToProblemResponse(statusCode int, err error) *webModel.ProblemResponse {
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return &webModel.ProblemResponse{
+			Type:   problemTypePrefix + "body-too-large",
+			Title:  "Request body is too large",
+			Status: http.StatusRequestEntityTooLarge,
+			Detail: fmt.Sprintf("request body must not exceed %d bytes", maxBytes.Limit),
+			Code:   "body-too-large",
+		}
+	}
+
+	for _, p := range problems {
+		if errors.Is(err, p.err) {
+			return &webModel.ProblemResponse{
+				Type:   problemTypePrefix + p.code,
+				Title:  p.title,
+				Status: p.status,
+				Detail: err.Error(),
+				Code:   p.code,
+			}
+		}
+	}
+
+	title := http.StatusText(statusCode)
+	return &webModel.ProblemResponse{
+		Type:   "about:blank",
+		Title:  title,
+		Status: statusCode,
+		Detail: err.Error(),
+		Code:   strings.ReplaceAll(strings.ToLower(title), " ", "-"),
+	}
+}
+
+func WriteError(w http.ResponseWriter, statusCode int, err error) {
+	problem := ToProblemResponse(statusCode, err)
+
+	var retry *service.RetryError
+	if errors.As(err, &retry) {
+		seconds := (retry.RetryAfter + time.Second - 1) / time.Second
+		w.Header().Set("Retry-After", strconv.Itoa(int(seconds)))
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
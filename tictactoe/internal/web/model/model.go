@@ -6,16 +6,149 @@ type MoveRequest struct {
 
 type MoveResponse struct {
 	GameID string     `json:"game_id"`
+	Mode   string     `json:"mode"`
+	Size   int        `json:"size"`
 	Field  [][]int    `json:"field"`
 	Status string     `json:"status"`
+	PlayerTurn int    `json:"player_turn"`
+	DrawOfferedBy string `json:"draw_offered_by,omitempty"`
+	AIJobID string    `json:"ai_job_id,omitempty"`
+	Moves  []MoveInfo `json:"moves"`
+	WinningLine []MoveInfo `json:"winning_line,omitempty"`
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+type AIJobResponse struct {
+	JobID      string    `json:"job_id"`
+	GameID     string    `json:"game_id"`
+	Status     string    `json:"status"`
+	Move       *MoveInfo `json:"move,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  string    `json:"created_at"`
+	FinishedAt string    `json:"finished_at,omitempty"`
+}
+
+type AcceptedMoveResponse struct {
+	Game *MoveResponse  `json:"game"`
+	Job  *AIJobResponse `json:"job"`
+}
+
+type ProblemResponse struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Code   string `json:"code"`
+}
+
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+type CreateGameRequest struct {
+	Size     int              `json:"size"`
+	Webhooks []WebhookRequest `json:"webhooks"`
+}
+
+type WebhookResponse struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
 }
 
 type CreateGameResponse struct {
 	GameID string     `json:"game_id"`
 	Field  [][]int    `json:"field"`
 	Status string     `json:"status"`
-}
\ No newline at end of file
+	Webhooks []WebhookResponse `json:"webhooks,omitempty"`
+}
+
+type AuthRequest struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+type AuthResponse struct {
+	UserID    string `json:"user_id"`
+	Login     string `json:"login"`
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type StreamTokenResponse struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type UserResponse struct {
+	UserID string `json:"user_id"`
+	Login  string `json:"login"`
+	Rating int    `json:"rating"`
+}
+
+type GameListResponse struct {
+	Games []*MoveResponse `json:"games"`
+}
+
+type StatsResponse struct {
+	Total      int `json:"total"`
+	Won        int `json:"won"`
+	Lost       int `json:"lost"`
+	Draw       int `json:"draw"`
+	InProgress int `json:"in_progress"`
+}
+
+type MatchRequest struct {
+	Size int `json:"size"`
+}
+
+type MatchResponse struct {
+	Game       *MoveResponse `json:"game"`
+	OpponentID string        `json:"opponent_id,omitempty"`
+	Opponent   string        `json:"opponent"`
+	Player     int           `json:"player"`
+}
+
+type SocketRequest struct {
+	Type    string `json:"type"`
+	Row     int    `json:"row"`
+	Col     int    `json:"col"`
+	Message string `json:"message"`
+	Accept  bool   `json:"accept"`
+}
+
+type MoveInfo struct {
+	Row    int `json:"row"`
+	Col    int `json:"col"`
+	Player int `json:"player"`
+}
+
+type GameUpdateResponse struct {
+	Type    string        `json:"type"`
+	Seq     uint64        `json:"seq,omitempty"`
+	GameID  string        `json:"game_id,omitempty"`
+	Mode    string        `json:"mode,omitempty"`
+	Status  string        `json:"status,omitempty"`
+	Game    *MoveResponse `json:"game,omitempty"`
+	Move    *MoveInfo     `json:"move,omitempty"`
+	UserID  string        `json:"user_id,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Size    int           `json:"size,omitempty"`
+	Waiting int           `json:"waiting,omitempty"`
+	Error   string        `json:"error,omitempty"`
+	Code    string        `json:"code,omitempty"`
+	Time    string        `json:"time"`
+}
+
+type CheckResponse struct {
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	Details    map[string]any `json:"details,omitempty"`
+	DurationMs float64        `json:"duration_ms"`
+}
+
+type HealthResponse struct {
+	Status string                   `json:"status"`
+	Checks map[string]CheckResponse `json:"checks,omitempty"`
+}
@@ -0,0 +1,13 @@
+package module
+
+import "net/http"
+
+type EventsHandlerInterface interface {
+	GameEvents(w http.ResponseWriter, r *http.Request)
+
+	LobbyEvents(w http.ResponseWriter, r *http.Request)
+
+	GameStreamToken(w http.ResponseWriter, r *http.Request)
+
+	LobbyStreamToken(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,215 @@
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/notify"
+	"tictactoe/internal/domain/service"
+	"tictactoe/internal/web/auth"
+	"tictactoe/internal/web/mapper"
+	webModel "tictactoe/internal/web/model"
+)
+
+const (
+	eventsHeartbeatInterval = 15 * time.Second
+	eventsRetryMillis       = 3000
+)
+
+type EventsHandler struct {
+	gameService service.GameService
+	hub         *notify.Hub
+	jwt         *auth.JwtProvider
+}
+
+func NewEventsHandler(gameService service.GameService, hub *notify.Hub, jwt *auth.JwtProvider) *EventsHandler {
+	return &EventsHandler{
+		gameService: gameService,
+		hub:         hub,
+		jwt:         jwt,
+	}
+}
+
+// GameStreamToken issues a stream token for the game's WebSocket and SSE
+// streams, for clients that cannot send the Authorization header there.
+func (h *EventsHandler) GameStreamToken(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := parseGameID(w, r)
+	if !ok {
+		return
+	}
+
+	userID, _, ok := loadOwnedGame(w, r, h.gameService, gameID)
+	if !ok {
+		return
+	}
+
+	h.writeStreamToken(w, userID, auth.GameStream(gameID))
+}
+
+func (h *EventsHandler) LobbyStreamToken(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		mapper.WriteError(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+		return
+	}
+
+	h.writeStreamToken(w, userID, auth.LobbyStream)
+}
+
+func (h *EventsHandler) writeStreamToken(w http.ResponseWriter, userID uuid.UUID, stream string) {
+	token, expiresAt, err := h.jwt.GenerateStreamToken(userID, stream)
+	if err != nil {
+		mapper.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	mapper.WriteJSON(w, http.StatusOK, &webModel.StreamTokenResponse{
+		Token:     token,
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	})
+}
+
+func (h *EventsHandler) GameEvents(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := parseGameID(w, r)
+	if !ok {
+		return
+	}
+
+	lastSeq, err := lastEventID(r)
+	if err != nil {
+		mapper.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, game, ok := loadOwnedGame(w, r, h.gameService, gameID)
+	if !ok {
+		return
+	}
+
+	subscription := h.hub.Subscribe(notify.GameTopic(gameID), lastSeq)
+	defer subscription.Close()
+
+	var snapshot *notify.Update
+	if !subscription.Resumed {
+		// Read the snapshot again after subscribing, so no update falls between them.
+		game, err = h.gameService.GetGame(r.Context(), gameID)
+		if err != nil {
+			mapper.WriteError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		snapshot = &notify.Update{
+			Seq:    subscription.Seq,
+			Type:   notify.UpdateSnapshot,
+			GameID: gameID,
+			Game:   game,
+			Time:   time.Now(),
+		}
+	}
+
+	h.stream(w, r, subscription, snapshot)
+}
+
+func (h *EventsHandler) LobbyEvents(w http.ResponseWriter, r *http.Request) {
+	lastSeq, err := lastEventID(r)
+	if err != nil {
+		mapper.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	subscription := h.hub.Subscribe(notify.LobbyTopic, lastSeq)
+	defer subscription.Close()
+
+	h.stream(w, r, subscription, nil)
+}
+
+func (h *EventsHandler) stream(w http.ResponseWriter, r *http.Request, subscription *notify.Subscription, snapshot *notify.Update) {
+	controller := http.NewResponseController(w)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && err != http.ErrNotSupported {
+		mapper.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", eventsRetryMillis)
+
+	if snapshot != nil {
+		if err := writeEvent(w, *snapshot); err != nil {
+			return
+		}
+	}
+
+	for _, update := range subscription.Missed {
+		if err := writeEvent(w, update); err != nil {
+			return
+		}
+	}
+
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case update, ok := <-subscription.Updates:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, update); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprintf(w, ": heartbeat %d\n\n", time.Now().Unix()); err != nil {
+				return
+			}
+
+		case <-r.Context().Done():
+			return
+		}
+
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, update notify.Update) error {
+	data, err := json.Marshal(mapper.ToGameUpdateResponse(update))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", update.Seq, update.Type, data)
+	return err
+}
+
+func lastEventID(r *http.Request) (uint64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	seq, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Last-Event-ID: %q", value)
+	}
+
+	return seq, nil
+}
@@ -8,4 +8,16 @@ type GameHandlerInterface interface {
 	CreateGame(w http.ResponseWriter, r *http.Request)
 	
 	GetGame(w http.ResponseWriter, r *http.Request)
+	
+	GetAIJob(w http.ResponseWriter, r *http.Request)
+	
+	GetImage(w http.ResponseWriter, r *http.Request)
+	
+	GetReplay(w http.ResponseWriter, r *http.Request)
+	
+	AbandonGame(w http.ResponseWriter, r *http.Request)
+	
+	ListGames(w http.ResponseWriter, r *http.Request)
+	
+	GetStats(w http.ResponseWriter, r *http.Request)
 }
\ No newline at end of file
@@ -1,67 +1,63 @@
 package module
 
 import (
-	"encoding/json"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"path"
+	"strconv"
 	"strings"
+	"time"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"tictactoe/internal/domain/service"
 	"tictactoe/internal/domain/model"
+	"tictactoe/internal/logging"
+	"tictactoe/internal/web/auth"
 	"tictactoe/internal/web/mapper"
+	"tictactoe/internal/web/render"
 	webModel "tictactoe/internal/web/model"
 
 )
 
+// replayCacheSize is the number of encoded replays kept in memory.
+const replayCacheSize = 32
+
 type GameHandler struct {
 	gameService service.GameService
+	replays     *render.ReplayCache
+	logger      *zap.Logger
 }
 
-func NewGameHandler(gameService service.GameService) *GameHandler {
+func NewGameHandler(gameService service.GameService, logger *zap.Logger) *GameHandler {
 	return &GameHandler{
 		gameService: gameService,
+		replays:     render.NewReplayCache(replayCacheSize),
+		logger:      logger,
 	}
 }
 
 func (h *GameHandler) MakeMove(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		mapper.WriteJSON(w, http.StatusMethodNotAllowed, 
-			mapper.ToErrorResponse(fmt.Errorf("method not allowed")))
-		return
-	}
-
-	pathParts := strings.Split(r.URL.Path, "/")
-	if len(pathParts) < 3 {
-		mapper.WriteJSON(w, http.StatusBadRequest,
-			mapper.ToErrorResponse(fmt.Errorf("invalid URL format")))
-		return
-	}
-	
-	gameIDStr := pathParts[2]
-	gameID, err := uuid.Parse(gameIDStr)
-	if err != nil {
-		mapper.WriteJSON(w, http.StatusBadRequest,
-			mapper.ToErrorResponse(fmt.Errorf("invalid game UUID: %v", err)))
+	gameID, ok := parseGameID(w, r)
+	if !ok {
 		return
 	}
 
 	var req webModel.MoveRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		mapper.WriteJSON(w, http.StatusBadRequest,
-			mapper.ToErrorResponse(fmt.Errorf("invalid JSON: %v", err)))
+	if err := mapper.DecodeJSON(r, &req); err != nil {
+		mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err))
 		return
 }
 
-	currentGame, err := h.gameService.GetGame(r.Context(), gameID)
-	if err != nil {
-		mapper.WriteJSON(w, http.StatusNotFound,
-			mapper.ToErrorResponse(fmt.Errorf("game not found: %v", err)))
+	userID, currentGame, ok := h.getOwnedGame(w, r, gameID)
+	if !ok {
 		return
 	}
 
 	if err := mapper.ValidateField(req.Field, currentGame.Size); err != nil {
-		mapper.WriteJSON(w, http.StatusBadRequest,
-			mapper.ToErrorResponse(err))
+		mapper.WriteError(w, http.StatusBadRequest, err)
 		return
 	}
 
@@ -71,120 +67,352 @@ func (h *GameHandler) MakeMove(w http.ResponseWriter, r *http.Request) {
 		mapper.FieldFromRequest(req.Field),
 	)
 	if err != nil {
-		mapper.WriteJSON(w, http.StatusInternalServerError,
-			mapper.ToErrorResponse(err))
+		mapper.WriteError(w, http.StatusInternalServerError, err)
 		return
 	}
 	
 	if !isValid {
-		mapper.WriteJSON(w, http.StatusBadRequest,
-			mapper.ToErrorResponse(fmt.Errorf("invalid game field: previous moves have been changed")))
+		mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("%w: previous moves have been changed", model.ErrInvalidMove))
 		return
 	}
 
-	userRow, userCol := h.findUserMove(currentGame.Field, req.Field)
+	player := currentGame.PlayerNumber(userID)
+
+	userRow, userCol := h.findUserMove(currentGame.Field, req.Field, player)
 	if userRow == -1 {
-		mapper.WriteJSON(w, http.StatusBadRequest,
-			mapper.ToErrorResponse(fmt.Errorf("invalid move: no valid user move found or multiple moves detected")))
+		mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("%w: no valid user move found or multiple moves detected", model.ErrInvalidMove))
+		return
+	}
+
+	if prefersAsync(r) {
+		h.makeMoveAsync(w, r, gameID, userID, userRow, userCol)
 		return
 	}
 
-	gameAfterUserMove, err := h.gameService.MakePlayerMove(r.Context(), gameID, userRow, userCol, 1)
+	game, err := h.gameService.PlayMove(r.Context(), gameID, userID, userRow, userCol)
 	if err != nil {
-		mapper.WriteJSON(w, http.StatusBadRequest,
-			mapper.ToErrorResponse(err))
+		mapper.WriteError(w, http.StatusBadRequest, err)
 		return
 	}
 
-	status, err := h.gameService.GetGameState(r.Context(), gameID)
+	mapper.WriteGame(w, r, http.StatusOK, game)
+}
+
+func (h *GameHandler) makeMoveAsync(w http.ResponseWriter, r *http.Request, gameID, userID uuid.UUID, row, col int) {
+	game, job, err := h.gameService.PlayMoveAsync(r.Context(), gameID, userID, row, col)
 	if err != nil {
-		mapper.WriteJSON(w, http.StatusInternalServerError,
-			mapper.ToErrorResponse(err))
+		mapper.WriteError(w, http.StatusBadRequest, err)
 		return
 	}
-	
-	if status != model.StateInProgress {
-		mapper.WriteJSON(w, http.StatusOK, mapper.ToMoveResponse(gameAfterUserMove))
+
+	if job == nil {
+		mapper.WriteGame(w, r, http.StatusOK, game)
+		return
+	}
+
+	w.Header().Set("Preference-Applied", "respond-async")
+	// The job lives under the move URL, so the versioned prefix is kept.
+	w.Header().Set("Location", path.Join(r.URL.Path, "jobs", job.ID.String()))
+	mapper.WriteJSON(w, http.StatusAccepted, mapper.ToAcceptedMoveResponse(game, job))
+}
+
+func (h *GameHandler) GetAIJob(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	gameID, ok := parseGameID(w, r)
+	if !ok {
+		return
+	}
+
+	jobID, err := uuid.Parse(r.PathValue("job"))
+	if err != nil {
+		mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid job UUID: %v", err))
 		return
 	}
 
-	gameAfterAIMove, err := h.gameService.GetNextMove(r.Context(), gameID)
+	job, err := h.gameService.GetAIJob(r.Context(), gameID, userID, jobID)
 	if err != nil {
-		mapper.WriteJSON(w, http.StatusInternalServerError,
-			mapper.ToErrorResponse(err))
+		mapper.WriteError(w, http.StatusBadRequest, err)
 		return
 	}
 
-	mapper.WriteJSON(w, http.StatusOK, mapper.ToMoveResponse(gameAfterAIMove))
+	if !job.IsFinished() {
+		w.Header().Set("Retry-After", "1")
+	}
+	mapper.WriteJSON(w, http.StatusOK, mapper.ToAIJobResponse(job))
 }
 
 func (h *GameHandler) CreateGame(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		mapper.WriteJSON(w, http.StatusMethodNotAllowed,
-			mapper.ToErrorResponse(fmt.Errorf("method not allowed")))
+	userID, ok := h.requireUser(w, r)
+	if !ok {
 		return
 	}
 
-	game, err := h.gameService.CreateGame(r.Context(), model.FieldSize)
+	req := webModel.CreateGameRequest{Size: model.FieldSize}
+	if r.ContentLength != 0 {
+		if err := mapper.DecodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+			mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err))
+			return
+		}
+		if req.Size == 0 {
+			req.Size = model.FieldSize
+		}
+	}
+
+	game, err := h.gameService.CreateGame(r.Context(), model.GameOptions{
+		Size:     req.Size,
+		OwnerID:  userID,
+		Webhooks: mapper.WebhooksFromRequest(req.Webhooks),
+	})
 	if err != nil {
-		mapper.WriteJSON(w, http.StatusInternalServerError,
-			mapper.ToErrorResponse(err))
+		mapper.WriteError(w, http.StatusBadRequest, err)
 		return
 	}
 
-	mapper.WriteJSON(w, http.StatusCreated, &webModel.CreateGameResponse{
-		GameID: game.ID.String(),
-		Field:  game.Field,
-		Status: string(game.State),
-	})
+	mapper.WriteJSON(w, http.StatusCreated, mapper.ToCreateGameResponse(game))
 }
 
 func (h *GameHandler) GetGame(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		mapper.WriteJSON(w, http.StatusMethodNotAllowed,
-			mapper.ToErrorResponse(fmt.Errorf("method not allowed")))
+	gameID, ok := parseGameID(w, r)
+	if !ok {
 		return
 	}
 
-	pathParts := strings.Split(r.URL.Path, "/")
-	if len(pathParts) < 3 {
-		mapper.WriteJSON(w, http.StatusBadRequest,
-			mapper.ToErrorResponse(fmt.Errorf("invalid URL format")))
+	_, game, ok := h.getOwnedGame(w, r, gameID)
+	if !ok {
 		return
 	}
-	
-	gameIDStr := pathParts[2]
-	gameID, err := uuid.Parse(gameIDStr)
+
+	mapper.WriteGame(w, r, http.StatusOK, game)
+}
+
+func (h *GameHandler) GetImage(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := parseGameID(w, r)
+	if !ok {
+		return
+	}
+
+	_, game, ok := h.getOwnedGame(w, r, gameID)
+	if !ok {
+		return
+	}
+
+	if value := r.URL.Query().Get("ply"); value != "" {
+		ply, err := strconv.Atoi(value)
+		if err != nil {
+			mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid ply: %v", err))
+			return
+		}
+		
+		game, err = render.AtPly(game, ply)
+		if err != nil {
+			mapper.WriteError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+
+	var (
+		buf         bytes.Buffer
+		contentType string
+		err         error
+	)
+	switch path.Ext(r.URL.Path) {
+	case ".svg":
+		contentType = "image/svg+xml"
+		err = render.SVG(&buf, game)
+	case ".png":
+		contentType = "image/png"
+		err = render.PNG(&buf, game)
+	default:
+		mapper.WriteError(w, http.StatusNotFound, fmt.Errorf("unsupported image format"))
+		return
+	}
+	if err != nil {
+		h.renderFailed(w, r, gameID, err)
+		return
+	}
+
+	h.writeImage(w, r, contentType, buf.Bytes())
+}
+
+func (h *GameHandler) GetReplay(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := parseGameID(w, r)
+	if !ok {
+		return
+	}
+
+	delay := render.DefaultFrameDelay
+	if value := r.URL.Query().Get("delay"); value != "" {
+		ms, err := strconv.Atoi(value)
+		if err != nil {
+			mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid delay: %v", err))
+			return
+		}
+		delay = time.Duration(ms) * time.Millisecond
+		if delay < render.MinFrameDelay || delay > render.MaxFrameDelay {
+			mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid delay: must be between %d and %d ms",
+					render.MinFrameDelay.Milliseconds(), render.MaxFrameDelay.Milliseconds()))
+			return
+		}
+	}
+
+	_, game, ok := h.getOwnedGame(w, r, gameID)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+
+	data, err := h.replays.GIF(game, delay)
 	if err != nil {
-		mapper.WriteJSON(w, http.StatusBadRequest,
-			mapper.ToErrorResponse(fmt.Errorf("invalid game UUID: %v", err)))
+		h.renderFailed(w, r, gameID, err)
+		return
+	}
+
+	h.writeImage(w, r, "image/gif", data)
+}
+
+// renderFailed answers a failed encode. Images are encoded into memory before
+// anything is sent, so the client gets a 500 instead of a cut-off image.
+func (h *GameHandler) renderFailed(w http.ResponseWriter, r *http.Request, gameID uuid.UUID, err error) {
+	logging.FromContext(r.Context(), h.logger).Error("failed to render game image",
+		zap.Stringer("game_id", gameID), zap.String("path", r.URL.Path), zap.Error(err))
+	mapper.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to render image"))
+}
+
+func (h *GameHandler) writeImage(w http.ResponseWriter, r *http.Request, contentType string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(data); err != nil {
+		logging.FromContext(r.Context(), h.logger).Debug("failed to send game image",
+			zap.String("path", r.URL.Path), zap.Error(err))
+	}
+}
+
+func (h *GameHandler) AbandonGame(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.requireUser(w, r)
+	if !ok {
 		return
 	}
 
-	game, err := h.gameService.GetGame(r.Context(), gameID)
+	gameID, ok := parseGameID(w, r)
+	if !ok {
+		return
+	}
+
+	game, err := h.gameService.AbandonGame(r.Context(), gameID, userID)
 	if err != nil {
-		mapper.WriteJSON(w, http.StatusNotFound,
-			mapper.ToErrorResponse(err))
+		mapper.WriteError(w, http.StatusBadRequest, err)
 		return
 	}
 
-	mapper.WriteJSON(w, http.StatusOK, mapper.ToMoveResponse(game))
+	mapper.WriteGame(w, r, http.StatusOK, game)
 }
 
-func (h *GameHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		mapper.WriteJSON(w, http.StatusMethodNotAllowed,
-			mapper.ToErrorResponse(fmt.Errorf("method not allowed")))
+func (h *GameHandler) ListGames(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.requireUser(w, r)
+	if !ok {
 		return
 	}
 
+	games, err := h.gameService.GetUserGames(r.Context(), userID)
+	if err != nil {
+		mapper.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	mapper.WriteJSON(w, http.StatusOK, mapper.ToGameListResponse(games))
+}
+
+func (h *GameHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := h.gameService.GetUserStats(r.Context(), userID)
+	if err != nil {
+		mapper.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	mapper.WriteJSON(w, http.StatusOK, mapper.ToStatsResponse(stats))
+}
+
+func (h *GameHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	mapper.WriteJSON(w, http.StatusOK, map[string]string{
 		"status":  "ok",
 		"service": "tictactoe",
 	})
 }
 
-func (h *GameHandler) findUserMove(originalField, newField [][]int) (int, int) {
+// prefersAsync reports whether the client asked for the AI reply to be computed
+// in the background (RFC 7240 "Prefer: respond-async").
+func prefersAsync(r *http.Request) bool {
+	for _, value := range r.Header.Values("Prefer") {
+		for _, preference := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(preference), "respond-async") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseGameID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid game UUID: %v", err))
+		return uuid.Nil, false
+	}
+	return gameID, true
+}
+
+func (h *GameHandler) requireUser(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		mapper.WriteError(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *GameHandler) getOwnedGame(w http.ResponseWriter, r *http.Request, gameID uuid.UUID) (uuid.UUID, *model.Game, bool) {
+	return loadOwnedGame(w, r, h.gameService, gameID)
+}
+
+// loadOwnedGame writes the error response itself: 401 without a user, the
+// mapped status (404 for an unknown game) when the game cannot be loaded and
+// 403 when the user does not play in it.
+func loadOwnedGame(w http.ResponseWriter, r *http.Request, gameService service.GameService, gameID uuid.UUID) (uuid.UUID, *model.Game, bool) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		mapper.WriteError(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+		return uuid.Nil, nil, false
+	}
+
+	game, err := gameService.GetGame(r.Context(), gameID)
+	if err != nil {
+		mapper.WriteError(w, http.StatusInternalServerError, err)
+		return uuid.Nil, nil, false
+	}
+
+	if !game.IsParticipant(userID) {
+		mapper.WriteError(w, http.StatusForbidden, service.ErrAccessDenied)
+		return uuid.Nil, nil, false
+	}
+
+	return userID, game, true
+}
+
+func (h *GameHandler) findUserMove(originalField, newField [][]int, player int) (int, int) {
 	if len(originalField) != len(newField) {
 		return -1, -1
 	}
@@ -202,7 +430,7 @@ func (h *GameHandler) findUserMove(originalField, newField [][]int) (int, int) {
 				if originalField[i][j] != 0 {
 					return -1, -1
 				}
-				if newField[i][j] != 1 {
+				if newField[i][j] != player {
 					return -1, -1
 				}
 				row, col = i, j
@@ -0,0 +1,9 @@
+package module
+
+import "net/http"
+
+type HealthHandlerInterface interface {
+	Livez(w http.ResponseWriter, r *http.Request)
+
+	Readyz(w http.ResponseWriter, r *http.Request)
+}
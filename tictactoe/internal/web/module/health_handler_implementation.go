@@ -0,0 +1,34 @@
+package module
+
+import (
+	"net/http"
+
+	"tictactoe/internal/health"
+	"tictactoe/internal/web/mapper"
+	webModel "tictactoe/internal/web/model"
+)
+
+type HealthHandler struct {
+	readiness *health.Checker
+}
+
+func NewHealthHandler(readiness *health.Checker) *HealthHandler {
+	return &HealthHandler{readiness: readiness}
+}
+
+func (h *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	mapper.WriteJSON(w, http.StatusOK, webModel.HealthResponse{Status: health.StatusOK})
+}
+
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := h.readiness.Run(r.Context())
+
+	status := http.StatusOK
+	if !report.Healthy() {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	mapper.WriteJSON(w, status, mapper.ToHealthResponse(report))
+}
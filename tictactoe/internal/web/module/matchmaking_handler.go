@@ -0,0 +1,7 @@
+package module
+
+import "net/http"
+
+type MatchmakingHandlerInterface interface {
+	FindMatch(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,49 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/service"
+	"tictactoe/internal/web/auth"
+	"tictactoe/internal/web/mapper"
+	webModel "tictactoe/internal/web/model"
+)
+
+type MatchmakingHandler struct {
+	matchmakingService service.MatchmakingService
+}
+
+func NewMatchmakingHandler(matchmakingService service.MatchmakingService) *MatchmakingHandler {
+	return &MatchmakingHandler{
+		matchmakingService: matchmakingService,
+	}
+}
+
+func (h *MatchmakingHandler) FindMatch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		mapper.WriteError(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+		return
+	}
+
+	req := webModel.MatchRequest{Size: model.FieldSize}
+	if err := mapper.DecodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err))
+		return
+	}
+	if req.Size == 0 {
+		req.Size = model.FieldSize
+	}
+
+	match, err := h.matchmakingService.FindMatch(r.Context(), userID, req.Size)
+	if err != nil {
+		mapper.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	mapper.WriteJSON(w, http.StatusOK, mapper.ToMatchResponse(match))
+}
@@ -0,0 +1,7 @@
+package module
+
+import "net/http"
+
+type GameSocketHandlerInterface interface {
+	ServeSocket(w http.ResponseWriter, r *http.Request)
+}
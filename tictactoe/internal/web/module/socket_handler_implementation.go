@@ -0,0 +1,237 @@
+package module
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"tictactoe/internal/domain/notify"
+	"tictactoe/internal/domain/service"
+	"tictactoe/internal/ratelimit"
+	"tictactoe/internal/web/auth"
+	"tictactoe/internal/web/mapper"
+	webModel "tictactoe/internal/web/model"
+)
+
+const (
+	socketWriteWait  = 10 * time.Second
+	socketPongWait   = 60 * time.Second
+	socketPingPeriod = socketPongWait * 9 / 10
+	socketMaxMessage = 4096
+)
+
+const (
+	socketRequestMove         = "move"
+	socketRequestChat         = "chat"
+	socketRequestDrawOffer    = "draw_offer"
+	socketRequestDrawResponse = "draw_response"
+	socketRequestAbandon      = "abandon"
+)
+
+type GameSocketHandler struct {
+	gameService service.GameService
+	hub         *notify.Hub
+	limits      ratelimit.Guard
+	upgrader    websocket.Upgrader
+}
+
+// NewGameSocketHandler accepts handshakes from the server's own host and from
+// origins for which originAllowed reports true. Moves sent over the socket
+// count against the same limits as REST moves.
+func NewGameSocketHandler(gameService service.GameService, hub *notify.Hub, limits ratelimit.Guard,
+	originAllowed func(origin string) bool) *GameSocketHandler {
+	return &GameSocketHandler{
+		gameService: gameService,
+		hub:         hub,
+		limits:      limits,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkOrigin(originAllowed),
+		},
+	}
+}
+
+// checkOrigin stops other sites from opening sockets with the user's
+// credentials. Clients other than browsers send no Origin and are accepted.
+func checkOrigin(originAllowed func(origin string) bool) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		return strings.EqualFold(u.Host, r.Host) || originAllowed(origin)
+	}
+}
+
+func (h *GameSocketHandler) ServeSocket(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		mapper.WriteError(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+		return
+	}
+
+	gameID, ok := parseGameID(w, r)
+	if !ok {
+		return
+	}
+
+	subscription := h.hub.Subscribe(notify.GameTopic(gameID), 0)
+	defer subscription.Close()
+
+	game, err := h.gameService.GetGame(r.Context(), gameID)
+	if err != nil {
+		mapper.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !game.IsParticipant(userID) {
+		mapper.WriteError(w, http.StatusForbidden, service.ErrAccessDenied)
+		return
+	}
+
+	client := h.limits.RequestKey(r)
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	replies := make(chan *webModel.GameUpdateResponse, 8)
+	go h.readLoop(ctx, cancel, conn, client, gameID, userID, replies)
+
+	snapshot := mapper.ToGameUpdateResponse(notify.Update{
+		Seq:    subscription.Seq,
+		Type:   notify.UpdateSnapshot,
+		GameID: gameID,
+		Game:   game,
+		Time:   time.Now(),
+	})
+	if err := h.write(conn, snapshot); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(socketPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case update, ok := <-subscription.Updates:
+			if !ok {
+				if h.hub.Closed() {
+					h.close(conn, websocket.CloseGoingAway, service.ErrShuttingDown.Error())
+				} else {
+					h.close(conn, websocket.CloseTryAgainLater, "subscriber is too slow")
+				}
+				return
+			}
+			if err := h.write(conn, mapper.ToGameUpdateResponse(update)); err != nil {
+				return
+			}
+
+		case reply := <-replies:
+			if err := h.write(conn, reply); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+
+		case <-ctx.Done():
+			h.close(conn, websocket.CloseNormalClosure, "")
+			return
+		}
+	}
+}
+
+func (h *GameSocketHandler) readLoop(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, client string,
+	gameID, userID uuid.UUID, replies chan<- *webModel.GameUpdateResponse) {
+	defer cancel()
+
+	conn.SetReadLimit(socketMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req webModel.SocketRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			h.reply(ctx, replies, fmt.Errorf("invalid JSON: %v", err))
+			continue
+		}
+
+		if err := h.handleRequest(ctx, client, gameID, userID, &req); err != nil {
+			h.reply(ctx, replies, err)
+		}
+	}
+}
+
+func (h *GameSocketHandler) handleRequest(ctx context.Context, client string, gameID, userID uuid.UUID, req *webModel.SocketRequest) error {
+	switch req.Type {
+	case socketRequestMove:
+		if err := h.limits.AllowMove(client, gameID); err != nil {
+			return err
+		}
+		_, err := h.gameService.PlayMove(ctx, gameID, userID, req.Row, req.Col)
+		return err
+
+	case socketRequestChat:
+		return h.gameService.SendChat(ctx, gameID, userID, req.Message)
+
+	case socketRequestDrawOffer:
+		_, err := h.gameService.OfferDraw(ctx, gameID, userID)
+		return err
+
+	case socketRequestDrawResponse:
+		_, err := h.gameService.RespondDraw(ctx, gameID, userID, req.Accept)
+		return err
+
+	case socketRequestAbandon:
+		_, err := h.gameService.AbandonGame(ctx, gameID, userID)
+		return err
+
+	default:
+		return fmt.Errorf("unknown message type: %q", req.Type)
+	}
+}
+
+func (h *GameSocketHandler) reply(ctx context.Context, replies chan<- *webModel.GameUpdateResponse, err error) {
+	select {
+	case replies <- mapper.ToSocketError(err):
+	case <-ctx.Done():
+	}
+}
+
+func (h *GameSocketHandler) write(conn *websocket.Conn, message *webModel.GameUpdateResponse) error {
+	conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+	return conn.WriteJSON(message)
+}
+
+func (h *GameSocketHandler) close(conn *websocket.Conn, code int, reason string) {
+	conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason))
+}
@@ -0,0 +1,11 @@
+package module
+
+import "net/http"
+
+type UserHandlerInterface interface {
+	Register(w http.ResponseWriter, r *http.Request)
+
+	Login(w http.ResponseWriter, r *http.Request)
+
+	Me(w http.ResponseWriter, r *http.Request)
+}
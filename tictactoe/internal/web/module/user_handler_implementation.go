@@ -0,0 +1,88 @@
+package module
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"tictactoe/internal/domain/model"
+	"tictactoe/internal/domain/service"
+	"tictactoe/internal/web/auth"
+	"tictactoe/internal/web/mapper"
+	webModel "tictactoe/internal/web/model"
+)
+
+type UserHandler struct {
+	userService service.UserService
+	jwt         *auth.JwtProvider
+}
+
+func NewUserHandler(userService service.UserService, jwt *auth.JwtProvider) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		jwt:         jwt,
+	}
+}
+
+func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req webModel.AuthRequest
+	if err := mapper.DecodeJSON(r, &req); err != nil {
+		mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err))
+		return
+	}
+
+	user, err := h.userService.Register(r.Context(), req.Login, req.Password)
+	if err != nil {
+		mapper.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.writeToken(w, http.StatusCreated, user)
+}
+
+func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req webModel.AuthRequest
+	if err := mapper.DecodeJSON(r, &req); err != nil {
+		mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err))
+		return
+	}
+
+	user, err := h.userService.Authenticate(r.Context(), req.Login, req.Password)
+	if err != nil {
+		mapper.WriteError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	h.writeToken(w, http.StatusOK, user)
+}
+
+func (h *UserHandler) Me(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		mapper.WriteError(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+		return
+	}
+
+	user, err := h.userService.GetUser(r.Context(), userID)
+	if err != nil {
+		mapper.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	mapper.WriteJSON(w, http.StatusOK, mapper.ToUserResponse(user))
+}
+
+func (h *UserHandler) writeToken(w http.ResponseWriter, status int, user *model.User) {
+	token, expiresAt, err := h.jwt.GenerateToken(user)
+	if err != nil {
+		mapper.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	mapper.WriteJSON(w, status, &webModel.AuthResponse{
+		UserID:    user.ID.String(),
+		Login:     user.Login,
+		Token:     token,
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	})
+}
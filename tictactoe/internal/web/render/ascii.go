@@ -0,0 +1,65 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"tictactoe/internal/domain/model"
+)
+
+func ASCII(game *model.Game) string {
+	var b strings.Builder
+
+	mode := "vs AI"
+	if game.Mode == model.ModePvP {
+		mode = "PvP"
+	}
+	fmt.Fprintf(&b, "Game %s (%s, %dx%d)\n\n", game.ID, mode, game.Size, game.Size)
+
+	b.WriteString("   ")
+	for col := 0; col < game.Size; col++ {
+		fmt.Fprintf(&b, "   %s", ColumnLabel(col))
+	}
+	b.WriteString("\n")
+
+	separator := "   +" + strings.Repeat("---+", game.Size) + "\n"
+	b.WriteString(separator)
+	for row := 0; row < game.Size; row++ {
+		fmt.Fprintf(&b, "%2d |", row+1)
+		for col := 0; col < game.Size; col++ {
+			fmt.Fprintf(&b, " %s |", Symbol(game.Field[row][col]))
+		}
+		b.WriteString("\n")
+		b.WriteString(separator)
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "Status: %s\n", game.State)
+	if !game.IsFinished() {
+		fmt.Fprintf(&b, "Turn: %s\n", Symbol(game.PlayerTurn))
+	}
+	if len(game.Moves) > 0 {
+		last := game.Moves[len(game.Moves)-1]
+		fmt.Fprintf(&b, "Last move: %s at %s\n", Symbol(last.Player), CellLabel(last.Row, last.Col))
+	}
+
+	return b.String()
+}
+
+func Symbol(player int) string {
+	switch player {
+	case model.PlayerX:
+		return "X"
+	case model.PlayerO:
+		return "O"
+	}
+	return " "
+}
+
+func ColumnLabel(col int) string {
+	return string(rune('A' + col))
+}
+
+func CellLabel(row, col int) string {
+	return fmt.Sprintf("%s%d", ColumnLabel(col), row+1)
+}
@@ -0,0 +1,91 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+
+	"tictactoe/internal/domain/model"
+)
+
+const (
+	maxCellSize = 100
+	minCellSize = 32
+	boardPixels = 400
+	boardMargin = 10
+	gridWidth   = 2
+)
+
+var (
+	colorBackground = color.NRGBA{0xff, 0xff, 0xff, 0xff}
+	colorGrid       = color.NRGBA{0x55, 0x55, 0x55, 0xff}
+	colorX          = color.NRGBA{0x1d, 0x5f, 0xbf, 0xff}
+	colorO          = color.NRGBA{0xc4, 0x37, 0x2b, 0xff}
+	colorLastMove   = color.NRGBA{0xfd, 0xf0, 0xc2, 0xff}
+	colorWin        = color.NRGBA{0x2e, 0x9e, 0x44, 0xcc}
+)
+
+func AtPly(game *model.Game, ply int) (*model.Game, error) {
+	if ply < 0 || ply > len(game.Moves) {
+		return nil, fmt.Errorf("invalid ply: must be between 0 and %d", len(game.Moves))
+	}
+	if ply == len(game.Moves) {
+		return game, nil
+	}
+
+	board := game.DeepCopy()
+	board.Field = model.NewField(game.Size)
+	board.Moves = board.Moves[:ply]
+	for _, move := range board.Moves {
+		board.Field[move.Row][move.Col] = move.Player
+	}
+
+	board.State = model.StateInProgress
+	board.PlayerTurn = model.PlayerX
+	if ply > 0 {
+		board.PlayerTurn = 3 - board.Moves[ply-1].Player
+	}
+	board.UpdateState()
+
+	return board, nil
+}
+
+// layout sizes the drawing for a board. Cells shrink as the board grows, so
+// a 10x10 image is not ten times the pixels of a 3x3 one.
+type layout struct {
+	size  int
+	cell  int
+	inset int
+	mark  int
+	win   int
+}
+
+func newLayout(game *model.Game) layout {
+	cell := max(minCellSize, min(maxCellSize, boardPixels/game.Size))
+	return layout{
+		size:  game.Size,
+		cell:  cell,
+		inset: cell * 22 / 100,
+		mark:  max(2, cell*8/100),
+		win:   max(3, cell/10),
+	}
+}
+
+func (l layout) imageSize() int {
+	return l.size*l.cell + 2*boardMargin
+}
+
+func (l layout) cellOrigin(row, col int) (int, int) {
+	return boardMargin + col*l.cell, boardMargin + row*l.cell
+}
+
+func (l layout) cellCenter(row, col int) (int, int) {
+	x, y := l.cellOrigin(row, col)
+	return x + l.cell/2, y + l.cell/2
+}
+
+func lastMove(game *model.Game) *model.Move {
+	if len(game.Moves) == 0 {
+		return nil
+	}
+	return &game.Moves[len(game.Moves)-1]
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+
+	"tictactoe/internal/domain/model"
+)
+
+const (
+	DefaultFrameDelay = 800 * time.Millisecond
+	MinFrameDelay     = 50 * time.Millisecond
+	MaxFrameDelay     = 10 * time.Second
+
+	// MaxReplayFrames caps the work of one replay: longer games skip plies.
+	MaxReplayFrames = 30
+
+	finalFrameHold = 3
+	paletteSteps   = 16
+)
+
+var replayPalette = buildPalette()
+
+func GIF(w io.Writer, game *model.Game, delay time.Duration) error {
+	if delay < MinFrameDelay || delay > MaxFrameDelay {
+		return fmt.Errorf("invalid frame delay: must be between %v and %v", MinFrameDelay, MaxFrameDelay)
+	}
+
+	plies := replayPlies(len(game.Moves))
+	animation := &gif.GIF{
+		Image: make([]*image.Paletted, 0, len(plies)),
+		Delay: make([]int, 0, len(plies)),
+	}
+
+	for i, ply := range plies {
+		board, err := AtPly(game, ply)
+		if err != nil {
+			return err
+		}
+
+		img := Image(board)
+		frame := image.NewPaletted(img.Bounds(), replayPalette)
+		draw.Draw(frame, frame.Bounds(), img, image.Point{}, draw.Src)
+
+		hundredths := int(delay / (10 * time.Millisecond))
+		if i == len(plies)-1 {
+			hundredths *= finalFrameHold
+		}
+
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, hundredths)
+	}
+
+	return gif.EncodeAll(w, animation)
+}
+
+// replayPlies picks the positions shown in a replay of a game with the given
+// number of moves: every ply of a short game, evenly spaced plies of a long
+// one. The first and the final positions are always shown.
+func replayPlies(moves int) []int {
+	frames := min(moves+1, MaxReplayFrames)
+	plies := make([]int, frames)
+	for i := 1; i < frames; i++ {
+		plies[i] = i * moves / (frames - 1)
+	}
+	return plies
+}
+
+func buildPalette() color.Palette {
+	backgrounds := []color.NRGBA{colorBackground, colorLastMove}
+	inks := []color.NRGBA{colorGrid, colorX, colorO, colorWin}
+
+	palette := color.Palette{opaque(colorBackground), opaque(colorLastMove)}
+	for _, background := range backgrounds {
+		for _, ink := range inks {
+			for step := 1; step <= paletteSteps; step++ {
+				palette = append(palette, mix(background, ink, float64(step)/paletteSteps))
+			}
+		}
+	}
+	for _, ink := range []color.NRGBA{colorX, colorO} {
+		palette = append(palette, mix(ink, colorWin, 1))
+	}
+
+	return palette
+}
+
+func mix(background, ink color.NRGBA, coverage float64) color.Color {
+	alpha := coverage * float64(ink.A) / 0xff
+	channel := func(src, dst uint8) uint8 {
+		return uint8(float64(src)*alpha + float64(dst)*(1-alpha) + 0.5)
+	}
+	return color.RGBA{channel(ink.R, background.R), channel(ink.G, background.G), channel(ink.B, background.B), 0xff}
+}
+
+func opaque(c color.NRGBA) color.Color {
+	return color.RGBA{c.R, c.G, c.B, 0xff}
+}
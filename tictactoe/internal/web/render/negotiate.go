@@ -0,0 +1,71 @@
+package render
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatJSON  = "json"
+	FormatASCII = "ascii"
+)
+
+func Format(r *http.Request) string {
+	switch strings.ToLower(r.URL.Query().Get("format")) {
+	case FormatASCII, "text":
+		return FormatASCII
+	case FormatJSON:
+		return FormatJSON
+	}
+
+	if textQuality(r.Header.Get("Accept")) > jsonQuality(r.Header.Get("Accept")) {
+		return FormatASCII
+	}
+	return FormatJSON
+}
+
+func textQuality(accept string) float64 {
+	return quality(accept, "text/plain", "text/*")
+}
+
+func jsonQuality(accept string) float64 {
+	if accept == "" {
+		return 1
+	}
+	return quality(accept, "application/json", "application/*")
+}
+
+func quality(accept, exact, group string) float64 {
+	precedence, q := 0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		var rank int
+		switch mediaType {
+		case exact:
+			rank = 3
+		case group:
+			rank = 2
+		case "*/*":
+			rank = 1
+		default:
+			continue
+		}
+
+		if rank > precedence {
+			precedence = rank
+			q = 1
+			if value, ok := params["q"]; ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+	}
+	return q
+}
@@ -0,0 +1,99 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"tictactoe/internal/domain/model"
+)
+
+func PNG(w io.Writer, game *model.Game) error {
+	return png.Encode(w, Image(game))
+}
+
+func Image(game *model.Game) *image.RGBA {
+	l := newLayout(game)
+	size := l.imageSize()
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colorBackground), image.Point{}, draw.Src)
+
+	if last := lastMove(game); last != nil {
+		x, y := l.cellOrigin(last.Row, last.Col)
+		draw.Draw(img, image.Rect(x, y, x+l.cell, y+l.cell), image.NewUniform(colorLastMove), image.Point{}, draw.Src)
+	}
+
+	end := boardMargin + game.Size*l.cell
+	for i := 0; i <= game.Size; i++ {
+		pos := boardMargin + i*l.cell
+		drawSegment(img, pos, boardMargin, pos, end, gridWidth, colorGrid)
+		drawSegment(img, boardMargin, pos, end, pos, gridWidth, colorGrid)
+	}
+
+	for row := 0; row < game.Size; row++ {
+		for col := 0; col < game.Size; col++ {
+			x, y := l.cellOrigin(row, col)
+			switch game.Field[row][col] {
+			case model.PlayerX:
+				drawSegment(img, x+l.inset, y+l.inset, x+l.cell-l.inset, y+l.cell-l.inset, l.mark, colorX)
+				drawSegment(img, x+l.cell-l.inset, y+l.inset, x+l.inset, y+l.cell-l.inset, l.mark, colorX)
+			case model.PlayerO:
+				cx, cy := l.cellCenter(row, col)
+				drawRing(img, cx, cy, l.cell/2-l.inset, l.mark, colorO)
+			}
+		}
+	}
+
+	if line := game.WinningLine(); line != nil {
+		x1, y1 := l.cellCenter(line[0].Row, line[0].Col)
+		x2, y2 := l.cellCenter(line[len(line)-1].Row, line[len(line)-1].Col)
+		drawSegment(img, x1, y1, x2, y2, l.win, colorWin)
+	}
+
+	return img
+}
+
+func drawSegment(img *image.RGBA, x1, y1, x2, y2, width int, c color.NRGBA) {
+	half := float64(width) / 2
+	ax, ay, bx, by := float64(x1), float64(y1), float64(x2), float64(y2)
+	dx, dy := bx-ax, by-ay
+	length := dx*dx + dy*dy
+
+	bounds := image.Rect(min(x1, x2)-width, min(y1, y2)-width, max(x1, x2)+width, max(y1, y2)+width).Intersect(img.Bounds())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+			t := 0.0
+			if length > 0 {
+				t = math.Max(0, math.Min(1, ((px-ax)*dx+(py-ay)*dy)/length))
+			}
+			distance := math.Hypot(px-(ax+t*dx), py-(ay+t*dy))
+			blend(img, x, y, c, half-distance+0.5)
+		}
+	}
+}
+
+func drawRing(img *image.RGBA, cx, cy, radius, width int, c color.NRGBA) {
+	half := float64(width) / 2
+	outer := radius + width
+
+	bounds := image.Rect(cx-outer, cy-outer, cx+outer, cy+outer).Intersect(img.Bounds())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			distance := math.Hypot(float64(x)+0.5-float64(cx), float64(y)+0.5-float64(cy))
+			blend(img, x, y, c, half-math.Abs(distance-float64(radius))+0.5)
+		}
+	}
+}
+
+func blend(img *image.RGBA, x, y int, c color.NRGBA, coverage float64) {
+	if coverage <= 0 {
+		return
+	}
+
+	dst := img.RGBAAt(x, y)
+	img.Set(x, y, mix(color.NRGBA{dst.R, dst.G, dst.B, 0xff}, c, math.Min(coverage, 1)))
+}
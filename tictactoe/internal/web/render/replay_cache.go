@@ -0,0 +1,73 @@
+package render
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/model"
+)
+
+type replayKey struct {
+	gameID  uuid.UUID
+	version int
+	delay   time.Duration
+}
+
+type replayEntry struct {
+	key  replayKey
+	data []byte
+}
+
+// ReplayCache keeps the encoded GIFs of the most recently requested replays.
+// A saved game gets a new version, so an entry never goes stale.
+type ReplayCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[replayKey]*list.Element
+}
+
+func NewReplayCache(maxEntries int) *ReplayCache {
+	return &ReplayCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[replayKey]*list.Element),
+	}
+}
+
+// GIF returns the replay of game, encoding it only if it is not cached.
+func (c *ReplayCache) GIF(game *model.Game, delay time.Duration) ([]byte, error) {
+	key := replayKey{gameID: game.ID, version: game.Version, delay: delay}
+
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		c.mu.Unlock()
+		return element.Value.(*replayEntry).data, nil
+	}
+	c.mu.Unlock()
+
+	var buf bytes.Buffer
+	if err := GIF(&buf, game, delay); err != nil {
+		return nil, err
+	}
+	data := buf.Bytes()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok {
+		if c.order.Len() >= c.maxEntries {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*replayEntry).key)
+		}
+		c.entries[key] = c.order.PushFront(&replayEntry{key: key, data: data})
+	}
+
+	return data, nil
+}
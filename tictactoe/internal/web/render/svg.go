@@ -0,0 +1,71 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"tictactoe/internal/domain/model"
+)
+
+func SVG(w io.Writer, game *model.Game) error {
+	l := newLayout(game)
+	size := l.imageSize()
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		size, size, size, size)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", size, size, hex(colorBackground))
+
+	if last := lastMove(game); last != nil {
+		x, y := l.cellOrigin(last.Row, last.Col)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+			x, y, l.cell, l.cell, hex(colorLastMove))
+	}
+
+	end := boardMargin + game.Size*l.cell
+	for i := 0; i <= game.Size; i++ {
+		pos := boardMargin + i*l.cell
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%d"/>`+"\n",
+			pos, boardMargin, pos, end, hex(colorGrid), gridWidth)
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%d"/>`+"\n",
+			boardMargin, pos, end, pos, hex(colorGrid), gridWidth)
+	}
+
+	for row := 0; row < game.Size; row++ {
+		for col := 0; col < game.Size; col++ {
+			x, y := l.cellOrigin(row, col)
+			switch game.Field[row][col] {
+			case model.PlayerX:
+				for _, line := range [][4]int{
+					{x + l.inset, y + l.inset, x + l.cell - l.inset, y + l.cell - l.inset},
+					{x + l.cell - l.inset, y + l.inset, x + l.inset, y + l.cell - l.inset},
+				} {
+					fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%d" stroke-linecap="round"/>`+"\n",
+						line[0], line[1], line[2], line[3], hex(colorX), l.mark)
+				}
+			case model.PlayerO:
+				cx, cy := l.cellCenter(row, col)
+				fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="%s" stroke-width="%d"/>`+"\n",
+					cx, cy, l.cell/2-l.inset, hex(colorO), l.mark)
+			}
+		}
+	}
+
+	if line := game.WinningLine(); line != nil {
+		x1, y1 := l.cellCenter(line[0].Row, line[0].Col)
+		x2, y2 := l.cellCenter(line[len(line)-1].Row, line[len(line)-1].Col)
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-opacity="%.2f" stroke-width="%d" stroke-linecap="round"/>`+"\n",
+			x1, y1, x2, y2, hex(colorWin), float64(colorWin.A)/0xff, l.win)
+	}
+
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func hex(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
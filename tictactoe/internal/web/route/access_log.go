@@ -0,0 +1,46 @@
+package route
+
+import (
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"tictactoe/internal/logging"
+)
+
+func AccessLogMiddleware(logger *zap.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := newResponseRecorder(w)
+
+			next.ServeHTTP(recorder, r)
+
+			route := r.Pattern
+			if route == "" {
+				route = "unmatched"
+			}
+
+			status := recorder.Status()
+			fields := []zap.Field{
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.String("route", route),
+				zap.Int("status", status),
+				zap.Int64("bytes", recorder.bytes),
+				zap.Duration("duration", time.Since(start)),
+				zap.String("remote", r.RemoteAddr),
+			}
+
+			requestLogger := logging.FromContext(r.Context(), logger)
+			switch {
+			case status >= http.StatusInternalServerError:
+				requestLogger.Error("request", fields...)
+			case status >= http.StatusBadRequest:
+				requestLogger.Warn("request", fields...)
+			default:
+				requestLogger.Info("request", fields...)
+			}
+		})
+	}
+}
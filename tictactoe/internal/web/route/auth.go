@@ -0,0 +1,82 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/web/auth"
+	"tictactoe/internal/web/mapper"
+)
+
+const (
+	bearerPrefix    = "Bearer "
+	tokenQueryParam = "access_token"
+)
+
+func AuthMiddleware(jwt *auth.JwtProvider) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if !strings.HasPrefix(header, bearerPrefix) {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				mapper.WriteError(w, http.StatusUnauthorized, fmt.Errorf("missing bearer token"))
+				return
+			}
+
+			authenticate(w, r, next, func() (uuid.UUID, error) {
+				return jwt.ParseToken(strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix)))
+			})
+		})
+	}
+}
+
+// StreamAuthMiddleware is AuthMiddleware for WebSocket and SSE routes, which
+// browsers open without custom headers. Besides the Authorization header it
+// accepts ?access_token= with a stream token issued for the stream that
+// stream(r) names; a regular API token is never accepted in the URL.
+func StreamAuthMiddleware(jwt *auth.JwtProvider, stream func(r *http.Request) string) Middleware {
+	withHeader := AuthMiddleware(jwt)
+
+	return func(next http.Handler) http.Handler {
+		headerAuth := withHeader(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.URL.Query().Get(tokenQueryParam)
+			if token == "" || r.Header.Get("Authorization") != "" {
+				headerAuth.ServeHTTP(w, r)
+				return
+			}
+
+			authenticate(w, r, next, func() (uuid.UUID, error) {
+				return jwt.ParseStreamToken(token, stream(r))
+			})
+		})
+	}
+}
+
+func authenticate(w http.ResponseWriter, r *http.Request, next http.Handler, parse func() (uuid.UUID, error)) {
+	userID, err := parse()
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		mapper.WriteError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(auth.WithUserID(r.Context(), userID)))
+}
+
+// GameStream names the stream of the game in the route's {id}. An invalid ID
+// names no stream, so no token matches it.
+func GameStream(r *http.Request) string {
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return ""
+	}
+	return auth.GameStream(gameID)
+}
+
+func LobbyStream(r *http.Request) string {
+	return auth.LobbyStream
+}
@@ -0,0 +1,130 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"tictactoe/internal/web/mapper"
+)
+
+type CORSOptions struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// CORSMiddleware answers preflight requests for routes that exist and adds
+// CORS headers to requests from allowed origins. hasRoute reports whether the
+// router serves the request; preflights for other routes fall through to it.
+func CORSMiddleware(options CORSOptions, hasRoute func(*http.Request) bool) Middleware {
+	allowAny := slices.Contains(options.AllowedOrigins, "*")
+	originAllowed := OriginMatcher(options.AllowedOrigins)
+	methods := strings.Join(options.AllowedMethods, ", ")
+	headers := strings.Join(options.AllowedHeaders, ", ")
+	exposed := strings.Join(options.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(options.MaxAge / time.Second))
+
+	allowedHeaders := make(map[string]bool, len(options.AllowedHeaders))
+	for _, header := range options.AllowedHeaders {
+		allowedHeaders[http.CanonicalHeaderKey(header)] = true
+	}
+
+	allowOrigin := func(w http.ResponseWriter, origin string) {
+		if allowAny && !options.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if options.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Origin")
+
+			method := r.Header.Get("Access-Control-Request-Method")
+			if r.Method != http.MethodOptions || method == "" {
+				if originAllowed(origin) {
+					allowOrigin(w, origin)
+					if exposed != "" {
+						w.Header().Set("Access-Control-Expose-Headers", exposed)
+					}
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+			probe := r.Clone(r.Context())
+			probe.Method = method
+			if !hasRoute(probe) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err := checkPreflight(originAllowed(origin), method, r.Header.Get("Access-Control-Request-Headers"),
+				options.AllowedMethods, allowedHeaders); err != nil {
+				mapper.WriteError(w, http.StatusForbidden, err)
+				return
+			}
+
+			allowOrigin(w, origin)
+			w.Header().Set("Access-Control-Allow-Methods", methods)
+			if headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			w.Header().Set("Access-Control-Max-Age", maxAge)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// OriginMatcher reports whether an origin matches one of the allowed origins
+// or patterns.
+func OriginMatcher(allowedOrigins []string) func(origin string) bool {
+	allowAny := slices.Contains(allowedOrigins, "*")
+	return func(origin string) bool {
+		if allowAny {
+			return true
+		}
+		for _, pattern := range allowedOrigins {
+			if matched, _ := path.Match(pattern, origin); matched {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func checkPreflight(originAllowed bool, method, requestedHeaders string, allowedMethods []string, allowedHeaders map[string]bool) error {
+	if !originAllowed {
+		return fmt.Errorf("CORS: origin is not allowed")
+	}
+	if !slices.Contains(allowedMethods, method) {
+		return fmt.Errorf("CORS: method %s is not allowed", method)
+	}
+	for _, header := range strings.Split(requestedHeaders, ",") {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		if header != "" && !allowedHeaders[header] {
+			return fmt.Errorf("CORS: header %s is not allowed", header)
+		}
+	}
+	return nil
+}
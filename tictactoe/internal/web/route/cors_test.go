@@ -0,0 +1,203 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	options := CORSOptions{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.preview.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		ExposedHeaders: []string{"Location"},
+		MaxAge:         10 * time.Minute,
+	}
+	hasRoute := func(r *http.Request) bool { return r.URL.Path == "/game" }
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		origin        string
+		requestMethod string
+		headers       string
+		status        int
+		allowOrigin   string
+		vary          []string
+		reachedNext   bool
+	}{
+		{
+			name:          "allowed preflight",
+			method:        http.MethodOptions,
+			path:          "/game",
+			origin:        "https://app.example.com",
+			requestMethod: http.MethodPost,
+			headers:       "authorization, content-type",
+			status:        http.StatusNoContent,
+			allowOrigin:   "https://app.example.com",
+			vary:          []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			name:          "preflight from pattern origin",
+			method:        http.MethodOptions,
+			path:          "/game",
+			origin:        "https://pr-1.preview.example.com",
+			requestMethod: http.MethodGet,
+			status:        http.StatusNoContent,
+			allowOrigin:   "https://pr-1.preview.example.com",
+			vary:          []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			name:          "preflight from rejected origin",
+			method:        http.MethodOptions,
+			path:          "/game",
+			origin:        "https://evil.example.org",
+			requestMethod: http.MethodPost,
+			status:        http.StatusForbidden,
+			vary:          []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			name:          "preflight for rejected method",
+			method:        http.MethodOptions,
+			path:          "/game",
+			origin:        "https://app.example.com",
+			requestMethod: http.MethodDelete,
+			status:        http.StatusForbidden,
+			vary:          []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			name:          "preflight for rejected header",
+			method:        http.MethodOptions,
+			path:          "/game",
+			origin:        "https://app.example.com",
+			requestMethod: http.MethodPost,
+			headers:       "X-Debug",
+			status:        http.StatusForbidden,
+			vary:          []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+		},
+		{
+			name:          "preflight for unknown route",
+			method:        http.MethodOptions,
+			path:          "/missing",
+			origin:        "https://app.example.com",
+			requestMethod: http.MethodPost,
+			status:        http.StatusOK,
+			vary:          []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+			reachedNext:   true,
+		},
+		{
+			name:        "request from allowed origin",
+			method:      http.MethodGet,
+			path:        "/game",
+			origin:      "https://app.example.com",
+			status:      http.StatusOK,
+			allowOrigin: "https://app.example.com",
+			vary:        []string{"Origin"},
+			reachedNext: true,
+		},
+		{
+			name:        "request from rejected origin",
+			method:      http.MethodGet,
+			path:        "/game",
+			origin:      "https://evil.example.org",
+			status:      http.StatusOK,
+			vary:        []string{"Origin"},
+			reachedNext: true,
+		},
+		{
+			name:        "request without origin",
+			method:      http.MethodGet,
+			path:        "/game",
+			status:      http.StatusOK,
+			reachedNext: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reachedNext := false
+			handler := CORSMiddleware(options, hasRoute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reachedNext = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.requestMethod != "" {
+				r.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+			if tt.headers != "" {
+				r.Header.Set("Access-Control-Request-Headers", tt.headers)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if reachedNext != tt.reachedNext {
+				t.Errorf("reached next handler = %t, want %t", reachedNext, tt.reachedNext)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.allowOrigin)
+			}
+			if got := w.Header().Values("Vary"); !slices.Equal(got, tt.vary) {
+				t.Errorf("Vary = %q, want %q", got, tt.vary)
+			}
+
+			if tt.status == http.StatusNoContent {
+				if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+					t.Errorf("Access-Control-Allow-Methods = %q, want %q", got, "GET, POST")
+				}
+				if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+					t.Errorf("Access-Control-Max-Age = %q, want %q", got, "600")
+				}
+			}
+			if tt.allowOrigin != "" && tt.reachedNext {
+				if got := w.Header().Get("Access-Control-Expose-Headers"); got != "Location" {
+					t.Errorf("Access-Control-Expose-Headers = %q, want %q", got, "Location")
+				}
+			}
+		})
+	}
+}
+
+func TestCORSMiddlewareWildcardOrigin(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials bool
+		allowOrigin string
+	}{
+		{name: "without credentials", allowOrigin: "*"},
+		{name: "with credentials", credentials: true, allowOrigin: "https://app.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := CORSOptions{
+				AllowedOrigins:   []string{"*"},
+				AllowedMethods:   []string{http.MethodGet},
+				AllowCredentials: tt.credentials,
+			}
+			handler := CORSMiddleware(options, func(*http.Request) bool { return true })(http.NotFoundHandler())
+
+			r := httptest.NewRequest(http.MethodGet, "/game", nil)
+			r.Header.Set("Origin", "https://app.example.com")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.allowOrigin)
+			}
+			if got := w.Header().Values("Vary"); !slices.Equal(got, []string{"Origin"}) {
+				t.Errorf("Vary = %q, want %q", got, []string{"Origin"})
+			}
+		})
+	}
+}
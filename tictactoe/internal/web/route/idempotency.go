@@ -0,0 +1,215 @@
+package route
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"tictactoe/internal/domain/service"
+	"tictactoe/internal/web/auth"
+	"tictactoe/internal/web/mapper"
+)
+
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	idempotencyReplayHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength = 255
+)
+
+type IdempotencyOptions struct {
+	TTL     time.Duration
+	MaxKeys int
+}
+
+type idempotencyEntry struct {
+	key         string
+	fingerprint [sha256.Size]byte
+	created     time.Time
+	done        bool
+	status      int
+	header      http.Header
+	body        []byte
+}
+
+// IdempotencyStore remembers the first response for each idempotency key.
+// Entries expire after the TTL; when MaxKeys entries are stored the oldest
+// one is dropped.
+type IdempotencyStore struct {
+	ttl     time.Duration
+	maxKeys int
+	now     func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func NewIdempotencyStore(options IdempotencyOptions) *IdempotencyStore {
+	return &IdempotencyStore{
+		ttl:     options.TTL,
+		maxKeys: options.MaxKeys,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// begin returns the entry stored for key. If there is none, it records an
+// in-flight entry for the caller, who must then complete or abandon it.
+func (s *IdempotencyStore) begin(key string, fingerprint [sha256.Size]byte) (idempotencyEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for oldest := s.order.Front(); oldest != nil && now.Sub(oldest.Value.(*idempotencyEntry).created) > s.ttl; oldest = s.order.Front() {
+		s.remove(oldest)
+	}
+
+	if element, ok := s.entries[key]; ok {
+		return *element.Value.(*idempotencyEntry), true
+	}
+
+	if s.order.Len() >= s.maxKeys {
+		s.remove(s.order.Front())
+	}
+
+	entry := &idempotencyEntry{key: key, fingerprint: fingerprint, created: now}
+	s.entries[key] = s.order.PushBack(entry)
+	return idempotencyEntry{}, false
+}
+
+func (s *IdempotencyStore) complete(key string, status int, header http.Header, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		entry := element.Value.(*idempotencyEntry)
+		entry.done = true
+		entry.status = status
+		entry.header = header
+		entry.body = body
+	}
+}
+
+func (s *IdempotencyStore) abandon(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		s.remove(element)
+	}
+}
+
+func (s *IdempotencyStore) remove(element *list.Element) {
+	s.order.Remove(element)
+	delete(s.entries, element.Value.(*idempotencyEntry).key)
+}
+
+// IdempotencyMiddleware replays the stored response for a repeated
+// Idempotency-Key. Keys are scoped to the user and the route; server errors
+// and 429 responses are not stored, so such requests can be retried.
+func IdempotencyMiddleware(store *IdempotencyStore) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("%w: %s must be at most %d characters",
+					service.ErrInvalidInput, idempotencyKeyHeader, maxIdempotencyKeyLength))
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				mapper.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := idempotencyScope(r, key)
+			fingerprint := sha256.Sum256(body)
+
+			entry, found := store.begin(scope, fingerprint)
+			if found {
+				replayIdempotent(w, entry, fingerprint)
+				return
+			}
+
+			capture := &capturedResponse{ResponseWriter: w}
+			stored := false
+			defer func() {
+				if !stored {
+					store.abandon(scope)
+				}
+			}()
+
+			next.ServeHTTP(capture, r)
+
+			if capture.status != 0 && capture.status < http.StatusInternalServerError && capture.status != http.StatusTooManyRequests {
+				store.complete(scope, capture.status, capture.header, capture.body.Bytes())
+				stored = true
+			}
+		})
+	}
+}
+
+func idempotencyScope(r *http.Request, key string) string {
+	user := ""
+	if userID, ok := auth.UserIDFromContext(r.Context()); ok {
+		user = userID.String()
+	}
+	return strings.Join([]string{user, r.Method, strings.TrimPrefix(r.URL.Path, APIVersionPrefix), key}, " ")
+}
+
+func replayIdempotent(w http.ResponseWriter, entry idempotencyEntry, fingerprint [sha256.Size]byte) {
+	switch {
+	case entry.fingerprint != fingerprint:
+		mapper.WriteError(w, http.StatusUnprocessableEntity, mapper.ErrIdempotencyKeyReused)
+	case !entry.done:
+		mapper.WriteError(w, http.StatusConflict, &service.RetryError{
+			Err:        mapper.ErrIdempotencyKeyInUse,
+			RetryAfter: time.Second,
+		})
+	default:
+		for name, values := range entry.header {
+			if _, ok := w.Header()[name]; !ok {
+				w.Header()[name] = values
+			}
+		}
+		w.Header().Set(idempotencyReplayHeader, "true")
+		w.WriteHeader(entry.status)
+		w.Write(entry.body)
+	}
+}
+
+type capturedResponse struct {
+	http.ResponseWriter
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (c *capturedResponse) WriteHeader(status int) {
+	if c.status == 0 {
+		c.status = status
+		c.header = c.ResponseWriter.Header().Clone()
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *capturedResponse) Write(data []byte) (int, error) {
+	if c.status == 0 {
+		c.WriteHeader(http.StatusOK)
+	}
+	c.body.Write(data)
+	return c.ResponseWriter.Write(data)
+}
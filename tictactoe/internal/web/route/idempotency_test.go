@@ -0,0 +1,190 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingHandler answers with its status and counts how often it ran, so a
+// test can tell a replayed response from a fresh one.
+type countingHandler struct {
+	mu     sync.Mutex
+	calls  int
+	status int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.calls++
+	calls := h.calls
+	h.mu.Unlock()
+
+	w.Header().Set("Location", "/game/1")
+	w.WriteHeader(h.status)
+	fmt.Fprintf(w, "call %d", calls)
+}
+
+func (h *countingHandler) Calls() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.calls
+}
+
+func newTestIdempotencyStore(maxKeys int) (*IdempotencyStore, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewIdempotencyStore(IdempotencyOptions{TTL: time.Minute, MaxKeys: maxKeys})
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func idempotentRequest(handler http.Handler, key, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/game", strings.NewReader(body))
+	r.Header.Set(idempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	store, _ := newTestIdempotencyStore(10)
+	next := &countingHandler{status: http.StatusCreated}
+	handler := IdempotencyMiddleware(store)(next)
+
+	first := idempotentRequest(handler, "key", `{"size":3}`)
+	replay := idempotentRequest(handler, "key", `{"size":3}`)
+
+	if calls := next.Calls(); calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+	if replay.Code != first.Code || replay.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %q, want %d %q", replay.Code, replay.Body, first.Code, first.Body)
+	}
+	if got := replay.Header().Get("Location"); got != "/game/1" {
+		t.Errorf("replayed Location = %q, want %q", got, "/game/1")
+	}
+	if got := replay.Header().Get(idempotencyReplayHeader); got != "true" {
+		t.Errorf("%s = %q, want %q", idempotencyReplayHeader, got, "true")
+	}
+	if got := first.Header().Get(idempotencyReplayHeader); got != "" {
+		t.Errorf("first response has %s = %q", idempotencyReplayHeader, got)
+	}
+}
+
+func TestIdempotencyRejectsDifferentBody(t *testing.T) {
+	store, _ := newTestIdempotencyStore(10)
+	next := &countingHandler{status: http.StatusCreated}
+	handler := IdempotencyMiddleware(store)(next)
+
+	idempotentRequest(handler, "key", `{"size":3}`)
+	w := idempotentRequest(handler, "key", `{"size":4}`)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if calls := next.Calls(); calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyRejectsRequestInFlight(t *testing.T) {
+	store, _ := newTestIdempotencyStore(10)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := IdempotencyMiddleware(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		idempotentRequest(handler, "key", `{}`)
+	}()
+	<-started
+
+	w := idempotentRequest(handler, "key", `{}`)
+	close(release)
+	<-done
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Retry-After = %q, want %q", got, "1")
+	}
+}
+
+func TestIdempotencyDoesNotStoreRetryableResponses(t *testing.T) {
+	tests := []struct {
+		status int
+		stored bool
+	}{
+		{http.StatusCreated, true},
+		{http.StatusBadRequest, true},
+		{http.StatusTooManyRequests, false},
+		{http.StatusInternalServerError, false},
+		{http.StatusServiceUnavailable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			store, _ := newTestIdempotencyStore(10)
+			next := &countingHandler{status: tt.status}
+			handler := IdempotencyMiddleware(store)(next)
+
+			idempotentRequest(handler, "key", `{}`)
+			w := idempotentRequest(handler, "key", `{}`)
+
+			wantCalls := 2
+			if tt.stored {
+				wantCalls = 1
+			}
+			if calls := next.Calls(); calls != wantCalls {
+				t.Errorf("handler ran %d times, want %d", calls, wantCalls)
+			}
+			if replayed := w.Header().Get(idempotencyReplayHeader) != ""; replayed != tt.stored {
+				t.Errorf("replayed = %t, want %t", replayed, tt.stored)
+			}
+		})
+	}
+}
+
+func TestIdempotencyEviction(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxKeys int
+		advance time.Duration
+		keys    []string
+		repeat  string
+		replay  bool
+	}{
+		{name: "within ttl", maxKeys: 10, advance: time.Minute, keys: []string{"a"}, repeat: "a", replay: true},
+		{name: "after ttl", maxKeys: 10, advance: time.Minute + time.Second, keys: []string{"a"}, repeat: "a", replay: false},
+		{name: "oldest over max keys", maxKeys: 2, keys: []string{"a", "b", "c"}, repeat: "a", replay: false},
+		{name: "newest over max keys", maxKeys: 2, keys: []string{"a", "b", "c"}, repeat: "c", replay: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, now := newTestIdempotencyStore(tt.maxKeys)
+			next := &countingHandler{status: http.StatusCreated}
+			handler := IdempotencyMiddleware(store)(next)
+
+			for _, key := range tt.keys {
+				idempotentRequest(handler, key, `{}`)
+			}
+			*now = now.Add(tt.advance)
+
+			w := idempotentRequest(handler, tt.repeat, `{}`)
+			if replayed := w.Header().Get(idempotencyReplayHeader) != ""; replayed != tt.replay {
+				t.Errorf("replayed = %t, want %t", replayed, tt.replay)
+			}
+		})
+	}
+}
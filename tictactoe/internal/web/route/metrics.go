@@ -0,0 +1,26 @@
+package route
+
+import (
+	"net/http"
+	"time"
+
+	"tictactoe/internal/metrics"
+)
+
+func MetricsMiddleware(m *metrics.Metrics) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := newResponseRecorder(w)
+
+			next.ServeHTTP(recorder, r)
+
+			route := r.Pattern
+			if route == "" {
+				route = "unmatched"
+			}
+
+			m.ObserveRequest(r.Method, route, recorder.Status(), time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/ratelimit"
+	"tictactoe/internal/web/mapper"
+)
+
+// RateLimitMiddleware rejects requests over the limit with 429 and Retry-After.
+// It must run after AuthMiddleware when clients are keyed by user.
+func RateLimitMiddleware(allow func(r *http.Request) error) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := allow(r); err != nil {
+				mapper.WriteError(w, http.StatusTooManyRequests, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func createLimit(guard ratelimit.Guard) Middleware {
+	return RateLimitMiddleware(func(r *http.Request) error {
+		return guard.AllowCreate(guard.RequestKey(r))
+	})
+}
+
+func moveLimit(guard ratelimit.Guard) Middleware {
+	return RateLimitMiddleware(func(r *http.Request) error {
+		gameID, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			// The handler rejects the ID with 400.
+			return nil
+		}
+		return guard.AllowMove(guard.RequestKey(r), gameID)
+	})
+}
+
+func renderLimit(guard ratelimit.Guard) Middleware {
+	return RateLimitMiddleware(func(r *http.Request) error {
+		return guard.AllowRender(guard.RequestKey(r))
+	})
+}
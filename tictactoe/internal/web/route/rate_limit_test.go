@@ -0,0 +1,79 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/service"
+	"tictactoe/internal/ratelimit"
+)
+
+// fixedGuard answers every check with the same error and records the keys it
+// was asked about.
+type fixedGuard struct {
+	*ratelimit.Keys
+	err   error
+	moves []uuid.UUID
+}
+
+func (g *fixedGuard) AllowCreate(key string) error { return g.err }
+
+func (g *fixedGuard) AllowMove(key string, gameID uuid.UUID) error {
+	g.moves = append(g.moves, gameID)
+	return g.err
+}
+
+func (g *fixedGuard) AllowRender(key string) error { return g.err }
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limited := &service.RetryError{
+		Err:        fmt.Errorf("%w: try again in 2s", service.ErrRateLimited),
+		RetryAfter: 1500 * time.Millisecond,
+	}
+	gameID := uuid.New()
+
+	tests := []struct {
+		name       string
+		limit      func(guard ratelimit.Guard) Middleware
+		id         string
+		err        error
+		status     int
+		retryAfter string
+		moves      int
+	}{
+		{name: "create allowed", limit: createLimit, status: http.StatusOK},
+		{name: "create limited", limit: createLimit, err: limited, status: http.StatusTooManyRequests, retryAfter: "2"},
+		{name: "render limited", limit: renderLimit, err: limited, status: http.StatusTooManyRequests, retryAfter: "2"},
+		{name: "move allowed", limit: moveLimit, id: gameID.String(), status: http.StatusOK, moves: 1},
+		{name: "move limited", limit: moveLimit, id: gameID.String(), err: limited, status: http.StatusTooManyRequests, retryAfter: "2", moves: 1},
+		{name: "move with invalid id", limit: moveLimit, id: "not-a-uuid", err: limited, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := &fixedGuard{Keys: ratelimit.NewKeys(ratelimit.ByIP, nil, nil), err: tt.err}
+			handler := tt.limit(guard)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, "/game", nil)
+			r.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.retryAfter)
+			}
+			if len(guard.moves) != tt.moves || (tt.moves > 0 && guard.moves[0] != gameID) {
+				t.Errorf("moves checked = %v, want %d for %s", guard.moves, tt.moves, gameID)
+			}
+		})
+	}
+}
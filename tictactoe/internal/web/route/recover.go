@@ -0,0 +1,39 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+	"tictactoe/internal/logging"
+	"tictactoe/internal/web/mapper"
+)
+
+func RecoverMiddleware(logger *zap.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				logging.FromContext(r.Context(), logger).Error("handler panicked",
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.Any("panic", recovered),
+					zap.StackSkip("stack", 2))
+
+				if recorder, ok := w.(*responseRecorder); ok && recorder.WroteHeader() {
+					return
+				}
+				mapper.WriteError(w, http.StatusInternalServerError, fmt.Errorf("internal server error"))
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,40 +1,137 @@
 package route
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
-	"tictactoe/internal/web/module"
+
+	"go.uber.org/zap"
+
+	"tictactoe/internal/metrics"
+	"tictactoe/internal/ratelimit"
+	"tictactoe/internal/web/auth"
 	"tictactoe/internal/web/mapper"
-	"fmt"
+	"tictactoe/internal/web/module"
+	"tictactoe/internal/web/ui"
 )
 
-func NewRouter(handler *module.GameHandler) http.Handler {
+const APIVersionPrefix = "/v1"
+
+type Options struct {
+	CORS         CORSOptions
+	MaxBodyBytes int64
+	RateLimit    ratelimit.Guard
+	Idempotency  IdempotencyOptions
+}
+
+func NewRouter(handler *module.GameHandler, userHandler *module.UserHandler, matchmakingHandler *module.MatchmakingHandler,
+	socketHandler *module.GameSocketHandler, eventsHandler *module.EventsHandler, healthHandler *module.HealthHandler, uiHandler *ui.Handler, jwt *auth.JwtProvider,
+	logger *zap.Logger, m *metrics.Metrics, options Options) http.Handler {
+	mux := http.NewServeMux()
+	authenticated := AuthMiddleware(jwt)
+
+	public := func(pattern string, handlerFunc http.HandlerFunc) {
+		handleVersioned(mux, pattern, handlerFunc)
+	}
+	protected := func(pattern string, handlerFunc http.HandlerFunc, middlewares ...Middleware) {
+		handleVersioned(mux, pattern, Chain(handlerFunc, append([]Middleware{authenticated}, middlewares...)...))
+	}
+	streaming := func(pattern string, handlerFunc http.HandlerFunc, stream func(*http.Request) string) {
+		handleVersioned(mux, pattern, Chain(handlerFunc, StreamAuthMiddleware(jwt, stream)))
+	}
+
+	idempotent := IdempotencyMiddleware(NewIdempotencyStore(options.Idempotency))
+	limitCreate := createLimit(options.RateLimit)
+	limitMove := moveLimit(options.RateLimit)
+	limitRender := renderLimit(options.RateLimit)
+
+	public("GET /health", handler.HealthCheck)
+	public("GET /livez", healthHandler.Livez)
+	public("GET /readyz", healthHandler.Readyz)
+	public("POST /auth/register", userHandler.Register)
+	public("POST /auth/login", userHandler.Login)
+
+	protected("GET /user/me", userHandler.Me)
+	protected("GET /user/stats", handler.GetStats)
+	protected("GET /games", handler.ListGames)
+	protected("POST /game", handler.CreateGame, idempotent, limitCreate)
+	protected("POST /matchmaking", matchmakingHandler.FindMatch, limitCreate)
+	protected("GET /game/{id}", handler.GetGame)
+	protected("POST /game/{id}", handler.MakeMove, idempotent, limitMove)
+	protected("GET /game/{id}/jobs/{job}", handler.GetAIJob)
+	protected("POST /game/{id}/abandon", handler.AbandonGame)
+	protected("GET /game/{id}/image.svg", handler.GetImage, limitRender)
+	protected("GET /game/{id}/image.png", handler.GetImage, limitRender)
+	protected("GET /game/{id}/replay.gif", handler.GetReplay, limitRender)
+	protected("POST /game/{id}/stream-token", eventsHandler.GameStreamToken)
+	protected("POST /lobby/stream-token", eventsHandler.LobbyStreamToken)
+	streaming("GET /game/{id}/ws", socketHandler.ServeSocket, GameStream)
+	streaming("GET /game/{id}/events", eventsHandler.GameEvents, GameStream)
+	streaming("GET /lobby/events", eventsHandler.LobbyEvents, LobbyStream)
+
+	mux.Handle("GET /{$}", uiHandler)
+	mux.Handle("GET /assets/", uiHandler)
+
+	return Chain(unmatchedAsProblem(mux),
+		RequestIDMiddleware,
+		TracingMiddleware,
+		AccessLogMiddleware(logger),
+		MetricsMiddleware(m),
+		RecoverMiddleware(logger),
+		CORSMiddleware(options.CORS, func(r *http.Request) bool {
+			_, pattern := mux.Handler(r)
+			return pattern != ""
+		}),
+		BodyLimitMiddleware(options.MaxBodyBytes),
+	)
+}
+
+func handleVersioned(mux *http.ServeMux, pattern string, handler http.Handler) {
+	method, path, _ := strings.Cut(pattern, " ")
+	mux.Handle(method+" "+APIVersionPrefix+path, handler)
+	mux.Handle(pattern, handler)
+}
+
+func unmatchedAsProblem(mux *http.ServeMux) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-		
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
+		fallback, pattern := mux.Handler(r)
+		if pattern != "" {
+			mux.ServeHTTP(w, r)
 			return
 		}
-			
-		switch {
-		case r.URL.Path == "/health" && r.Method == http.MethodGet:
-			handler.HealthCheck(w, r)
-			
-		case r.URL.Path == "/game" && r.Method == http.MethodPost:
-			handler.CreateGame(w, r)
-			
-		case strings.HasPrefix(r.URL.Path, "/game/") && r.Method == http.MethodGet:
-			handler.GetGame(w, r)
-			
-		case strings.HasPrefix(r.URL.Path, "/game/") && r.Method == http.MethodPost:
-			handler.MakeMove(w, r)
-			
-		default:
-			mapper.WriteJSON(w, http.StatusNotFound, 
-				mapper.ToErrorResponse(fmt.Errorf("endpoint not found")))
+
+		probe := &headerRecorder{header: http.Header{}}
+		fallback.ServeHTTP(probe, r)
+
+		if probe.status == http.StatusMethodNotAllowed {
+			w.Header().Set("Allow", probe.header.Get("Allow"))
+			mapper.WriteError(w, http.StatusMethodNotAllowed,
+				fmt.Errorf("method %s is not allowed for %s", r.Method, r.URL.Path))
+			return
 		}
+
+		mapper.WriteError(w, http.StatusNotFound, fmt.Errorf("endpoint not found"))
 	})
 }
+
+type headerRecorder struct {
+	header http.Header
+	status int
+}
+
+func (r *headerRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *headerRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return len(data), nil
+}
+
+func (r *headerRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
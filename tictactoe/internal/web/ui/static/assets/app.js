@@ -0,0 +1,368 @@
+"use strict";
+
+const MIN_SIZE = 3;
+const MAX_SIZE = 10;
+const IN_PROGRESS = "Game in progress";
+const AI_THINKING = "AI thinking";
+const STREAM_EVENTS = ["snapshot", "move", "state", "draw_offer", "draw_declined", "abandoned"];
+const REPLAY_DELAY = 700;
+const STREAM_RETRY_DELAY = 3000;
+
+const state = {
+  token: localStorage.getItem("token"),
+  game: null,
+  player: 0,
+  ply: null,
+  stream: null,
+  timer: null,
+};
+
+const $ = (id) => document.getElementById(id);
+
+async function api(method, path, body) {
+  const options = { method, headers: {} };
+  if (state.token) {
+    options.headers["Authorization"] = "Bearer " + state.token;
+  }
+  if (body !== undefined) {
+    options.headers["Content-Type"] = "application/json";
+    options.body = JSON.stringify(body);
+  }
+
+  const response = await fetch(path, options);
+  const data = await response.json().catch(() => ({}));
+  if (response.status === 401 && state.token) {
+    logout();
+  }
+  if (!response.ok) {
+    throw new Error(data.detail || data.title || response.statusText);
+  }
+  return data;
+}
+
+function showError(err) {
+  $("error").textContent = err ? err.message : "";
+}
+
+async function run(action) {
+  showError(null);
+  try {
+    await action();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function start() {
+  const sizes = $("new-game").elements.size;
+  for (let size = MIN_SIZE; size <= MAX_SIZE; size++) {
+    sizes.add(new Option(size + " x " + size, size));
+  }
+
+  if (!state.token) {
+    showAuth();
+    return;
+  }
+
+  try {
+    const user = await api("GET", "/user/me");
+    showApp(user.login);
+  } catch (err) {
+    showAuth();
+  }
+}
+
+function showAuth() {
+  $("auth").hidden = false;
+  $("app").hidden = true;
+  $("session").hidden = true;
+}
+
+async function showApp(login) {
+  $("auth").hidden = true;
+  $("app").hidden = false;
+  $("session").hidden = false;
+  $("login").textContent = login;
+  await loadGames();
+}
+
+function logout() {
+  state.token = null;
+  localStorage.removeItem("token");
+  closeGame();
+  showAuth();
+}
+
+async function authenticate(event) {
+  event.preventDefault();
+  const form = event.target;
+  const action = event.submitter ? event.submitter.dataset.action : "login";
+
+  await run(async () => {
+    const data = await api("POST", "/auth/" + action, {
+      login: form.elements.login.value,
+      password: form.elements.password.value,
+    });
+    state.token = data.token;
+    localStorage.setItem("token", data.token);
+    form.reset();
+    await showApp(data.login);
+  });
+}
+
+async function loadGames() {
+  const data = await api("GET", "/games");
+  const list = $("games");
+  list.replaceChildren();
+
+  data.games.sort((a, b) => (isActive(a) ? 0 : 1) - (isActive(b) ? 0 : 1));
+  for (const game of data.games) {
+    const item = document.createElement("li");
+    item.textContent = (game.mode === "pvp" ? "PvP" : "vs AI") + " " + game.size + "x" + game.size + " - " + game.status;
+    item.classList.toggle("active", state.game !== null && state.game.game_id === game.game_id);
+    item.addEventListener("click", () => openGame(game, 0));
+    list.append(item);
+  }
+}
+
+async function createGame(event) {
+  event.preventDefault();
+  const form = event.target;
+  const size = Number(form.elements.size.value);
+  const button = form.querySelector("button");
+
+  await run(async () => {
+    if (form.elements.mode.value === "pvp") {
+      button.disabled = true;
+      $("status").textContent = "Looking for an opponent...";
+      try {
+        const match = await api("POST", "/matchmaking", { size });
+        openGame(match.game, match.player);
+      } finally {
+        button.disabled = false;
+      }
+    } else {
+      const created = await api("POST", "/game", { size });
+      openGame(await api("GET", "/game/" + created.game_id), 0);
+    }
+    await loadGames();
+  });
+}
+
+function openGame(game, player) {
+  closeGame();
+  state.game = game;
+  state.player = player;
+  state.ply = null;
+  $("game").hidden = false;
+  render();
+  loadGames().catch(showError);
+
+  if (isActive(game)) {
+    openStream(game.game_id, null).catch(showError);
+  }
+}
+
+// openStream connects with a short-lived stream token instead of the login
+// token, which must not appear in URLs. The stream token expires after a
+// minute, so a dropped connection is reopened with a new one and resumes
+// after the last received event.
+async function openStream(gameID, lastEventID) {
+  const { token } = await api("POST", "/game/" + gameID + "/stream-token");
+  if (!state.game || state.game.game_id !== gameID || !isActive(state.game)) {
+    return;
+  }
+
+  let url = "/game/" + gameID + "/events?access_token=" + encodeURIComponent(token);
+  if (lastEventID !== null) {
+    url += "&last_event_id=" + encodeURIComponent(lastEventID);
+  }
+
+  const stream = new EventSource(url);
+  state.stream = stream;
+  for (const type of STREAM_EVENTS) {
+    stream.addEventListener(type, (event) => {
+      lastEventID = event.lastEventId;
+      const update = JSON.parse(event.data);
+      if (update.game) {
+        updateGame(update.game);
+      }
+    });
+  }
+  stream.addEventListener("error", () => {
+    if (stream.readyState === EventSource.CLOSED && state.stream === stream) {
+      state.stream = null;
+      setTimeout(() => {
+        if (state.stream === null) {
+          openStream(gameID, lastEventID).catch(showError);
+        }
+      }, STREAM_RETRY_DELAY);
+    }
+  });
+}
+
+function isActive(game) {
+  return game.status === IN_PROGRESS || game.status === AI_THINKING;
+}
+
+function closeGame() {
+  if (state.stream) {
+    state.stream.close();
+    state.stream = null;
+  }
+  stopReplay();
+  state.game = null;
+  $("game").hidden = true;
+}
+
+function updateGame(game) {
+  if (!state.game || state.game.game_id !== game.game_id) {
+    return;
+  }
+  const finished = isActive(state.game) && !isActive(game);
+  state.game = game;
+  render();
+  if (finished && state.stream) {
+    state.stream.close();
+    state.stream = null;
+    loadGames().catch(showError);
+  }
+}
+
+async function play(row, col) {
+  const game = state.game;
+  const mark = state.player || game.player_turn;
+  const field = game.field.map((cells) => cells.slice());
+  field[row][col] = mark;
+
+  await run(async () => {
+    updateGame(await api("POST", "/game/" + game.game_id, { field }));
+  });
+}
+
+async function abandon() {
+  await run(async () => {
+    updateGame(await api("POST", "/game/" + state.game.game_id + "/abandon"));
+    await loadGames();
+  });
+}
+
+function fieldAt(game, ply) {
+  const field = [];
+  for (let i = 0; i < game.size; i++) {
+    field.push(new Array(game.size).fill(0));
+  }
+  for (const move of game.moves.slice(0, ply)) {
+    field[move.row][move.col] = move.player;
+  }
+  return field;
+}
+
+function describe(move) {
+  return "row " + (move.row + 1) + ", column " + (move.col + 1);
+}
+
+function render() {
+  const game = state.game;
+  const moves = game.moves || [];
+  const finished = !isActive(game);
+  const replaying = state.ply !== null;
+  const ply = replaying ? state.ply : moves.length;
+  const field = replaying ? fieldAt(game, ply) : game.field;
+
+  let status = game.status;
+  if (game.status === IN_PROGRESS) {
+    status += game.mode === "pvp"
+      ? " - " + (game.player_turn === state.player ? "your turn" : game.player_turn === 1 ? "X to move" : "O to move")
+      : " - your turn";
+  }
+  $("status").textContent = status;
+
+  const last = ply > 0 ? moves[ply - 1] : null;
+  const winning = new Set();
+  if (ply === moves.length) {
+    for (const cell of game.winning_line || []) {
+      winning.add(cell.row + ":" + cell.col);
+    }
+  }
+
+  const board = $("board");
+  board.style.gridTemplateColumns = "repeat(" + game.size + ", 1fr)";
+  board.replaceChildren();
+  field.forEach((cells, row) => {
+    cells.forEach((value, col) => {
+      const cell = document.createElement("button");
+      cell.type = "button";
+      cell.className = "cell" + (value === 1 ? " x" : value === 2 ? " o" : "");
+      cell.textContent = value === 1 ? "X" : value === 2 ? "O" : "";
+      cell.classList.toggle("last", last !== null && last.row === row && last.col === col);
+      cell.classList.toggle("win", winning.has(row + ":" + col));
+      cell.disabled = finished || replaying || value !== 0;
+      cell.addEventListener("click", () => play(row, col));
+      board.append(cell);
+    });
+  });
+
+  let lastText = "";
+  if (game.mode !== "pvp") {
+    const ai = moves.slice(0, ply).filter((move) => move.player === 2).pop();
+    if (ai) {
+      lastText = "Computer played " + describe(ai);
+    }
+  } else if (last) {
+    lastText = (last.player === 1 ? "X" : "O") + " played " + describe(last);
+  }
+  $("last-move").textContent = lastText;
+
+  $("abandon").hidden = finished;
+  $("replay").hidden = !finished || moves.length === 0;
+  $("replay-ply").max = moves.length;
+  $("replay-ply").value = ply;
+  $("replay-label").textContent = "Move " + ply + " / " + moves.length;
+}
+
+function seek(ply) {
+  const total = state.game.moves.length;
+  ply = Math.max(0, Math.min(total, ply));
+  state.ply = ply === total ? null : ply;
+  render();
+}
+
+function currentPly() {
+  return state.ply === null ? state.game.moves.length : state.ply;
+}
+
+function togglePlayback() {
+  if (state.timer) {
+    stopReplay();
+    return;
+  }
+  if (state.ply === null) {
+    seek(0);
+  }
+  $("replay-play").textContent = "Pause";
+  state.timer = setInterval(() => {
+    seek(currentPly() + 1);
+    if (state.ply === null) {
+      stopReplay();
+    }
+  }, REPLAY_DELAY);
+}
+
+function stopReplay() {
+  clearInterval(state.timer);
+  state.timer = null;
+  $("replay-play").textContent = "Play";
+}
+
+$("auth-form").addEventListener("submit", authenticate);
+$("logout").addEventListener("click", logout);
+$("new-game").addEventListener("submit", createGame);
+$("abandon").addEventListener("click", abandon);
+$("replay-start").addEventListener("click", () => seek(0));
+$("replay-prev").addEventListener("click", () => seek(currentPly() - 1));
+$("replay-next").addEventListener("click", () => seek(currentPly() + 1));
+$("replay-ply").addEventListener("input", (event) => seek(Number(event.target.value)));
+$("replay-play").addEventListener("click", togglePlayback);
+
+start();
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed static
+var static embed.FS
+
+type Handler struct {
+	files http.Handler
+}
+
+func NewHandler() (*Handler, error) {
+	root, err := fs.Sub(static, "static")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{files: http.FileServer(http.FS(root))}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache")
+	h.files.ServeHTTP(w, r)
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type deadLetterEntry struct {
+	FailedAt   string          `json:"failed_at"`
+	DeliveryID string          `json:"delivery_id"`
+	Event      string          `json:"event"`
+	GameID     string          `json:"game_id"`
+	URL        string          `json:"url"`
+	Attempts   int             `json:"attempts"`
+	Error      string          `json:"error"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+type DeadLetterLog struct {
+	mu     sync.Mutex
+	file   *os.File
+	logger *zap.Logger
+}
+
+func NewDeadLetterLog(path string, logger *zap.Logger) (*DeadLetterLog, error) {
+	if path == "" {
+		return &DeadLetterLog{logger: logger}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open webhook dead-letter log: %w", err)
+	}
+
+	return &DeadLetterLog{file: file, logger: logger}, nil
+}
+
+func (l *DeadLetterLog) Write(item delivery, cause error) {
+	l.logger.Error("giving up on webhook delivery",
+		zap.Stringer("delivery_id", item.event.ID),
+		zap.String("event", string(item.event.Type)),
+		zap.Stringer("game_id", item.event.GameID),
+		zap.String("url", item.target.URL),
+		zap.Int("attempts", item.attempt),
+		zap.Error(cause))
+
+	if l.file == nil {
+		return
+	}
+
+	line, err := json.Marshal(deadLetterEntry{
+		FailedAt:   time.Now().Format(time.RFC3339Nano),
+		DeliveryID: item.event.ID.String(),
+		Event:      string(item.event.Type),
+		GameID:     item.event.GameID.String(),
+		URL:        item.target.URL,
+		Attempts:   item.attempt,
+		Error:      cause.Error(),
+		Payload:    item.body,
+	})
+	if err != nil {
+		l.logger.Error("failed to encode dead-letter entry", zap.Error(err))
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		l.logger.Error("failed to write dead-letter entry", zap.Error(err))
+	}
+}
+
+func (l *DeadLetterLog) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
@@ -0,0 +1,342 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"tictactoe/internal/domain/event"
+	"tictactoe/internal/domain/model"
+)
+
+const (
+	HeaderEvent     = "X-Tictactoe-Event"
+	HeaderDelivery  = "X-Tictactoe-Delivery"
+	HeaderTimestamp = "X-Tictactoe-Timestamp"
+	HeaderSignature = "X-Tictactoe-Signature"
+
+	queueSize = 1024
+)
+
+type Options struct {
+	Global         []model.Webhook
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Timeout        time.Duration
+	Workers        int
+	DeadLetter     *DeadLetterLog
+}
+
+type delivery struct {
+	target  model.Webhook
+	trusted bool
+	event   event.Event
+	body    []byte
+	attempt int
+}
+
+// Dispatcher delivers events to webhooks. Global webhooks come from the
+// server configuration and are trusted; webhooks registered with a game may
+// only reach public addresses.
+//
+// Workers only send requests. A failed delivery waits for its retry on a
+// timer and is put back in the queue, so slow or broken receivers do not hold
+// up the workers.
+type Dispatcher struct {
+	options Options
+	client  *http.Client
+	trusted *http.Client
+	queue   chan delivery
+	logger  *zap.Logger
+
+	mu      sync.RWMutex
+	closed  bool
+	stopped bool
+	retries map[*time.Timer]scheduledRetry
+	stop    chan struct{}
+	workers sync.WaitGroup
+	pending sync.WaitGroup
+}
+
+type scheduledRetry struct {
+	item  delivery
+	cause error
+}
+
+func NewDispatcher(options Options, logger *zap.Logger) *Dispatcher {
+	d := &Dispatcher{
+		options: options,
+		client:  newClient(options.Timeout, true),
+		trusted: newClient(options.Timeout, false),
+		queue:   make(chan delivery, queueSize),
+		logger:  logger,
+		retries: make(map[*time.Timer]scheduledRetry),
+		stop:    make(chan struct{}),
+	}
+
+	for i := 0; i < options.Workers; i++ {
+		d.workers.Add(1)
+		go d.work()
+	}
+
+	return d
+}
+
+func (d *Dispatcher) Name() string {
+	return "webhooks"
+}
+
+func (d *Dispatcher) Types() []event.Type {
+	return nil
+}
+
+// Reliable asks the event bus not to drop events: receivers cannot ask for a
+// missed notification again.
+func (d *Dispatcher) Reliable() bool {
+	return true
+}
+
+func (d *Dispatcher) Handle(ctx context.Context, ev event.Event) {
+	targets := d.targets(ev)
+	if len(targets) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(newPayload(ev))
+	if err != nil {
+		d.logger.Error("failed to encode webhook payload",
+			zap.String("event", string(ev.Type)), zap.Stringer("game_id", ev.GameID), zap.Error(err))
+		return
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, item := range targets {
+		item.body = body
+
+		if d.closed {
+			d.options.DeadLetter.Write(item, fmt.Errorf("dispatcher is shutting down"))
+			continue
+		}
+
+		d.pending.Add(1)
+		select {
+		case d.queue <- item:
+		default:
+			d.fail(item, fmt.Errorf("delivery queue is full"))
+		}
+	}
+}
+
+// Close stops accepting events and waits for queued deliveries and scheduled
+// retries. When ctx expires, the remaining deliveries are dead-lettered.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.pending.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+		d.halt()
+		for drained := false; !drained; {
+			select {
+			case item := <-d.queue:
+				d.fail(item, fmt.Errorf("dispatcher stopped before delivery"))
+			case <-done:
+				drained = true
+			}
+		}
+	}
+
+	d.halt()
+	d.workers.Wait()
+	return err
+}
+
+// halt stops the workers and dead-letters the retries that have not fired.
+func (d *Dispatcher) halt() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return
+	}
+	d.stopped = true
+	close(d.stop)
+
+	for timer, retry := range d.retries {
+		if timer.Stop() {
+			d.fail(retry.item, fmt.Errorf("dispatcher stopped before retry: %w", retry.cause))
+		}
+	}
+	clear(d.retries)
+}
+
+func (d *Dispatcher) targets(ev event.Event) []delivery {
+	var targets []delivery
+
+	for _, webhook := range d.options.Global {
+		if slices.Contains(webhook.Events, string(ev.Type)) {
+			targets = append(targets, delivery{target: webhook, trusted: true, event: ev, attempt: 1})
+		}
+	}
+
+	if ev.Game != nil {
+		for _, webhook := range ev.Game.Webhooks {
+			if slices.Contains(webhook.Events, string(ev.Type)) {
+				targets = append(targets, delivery{target: webhook, event: ev, attempt: 1})
+			}
+		}
+	}
+
+	return targets
+}
+
+func (d *Dispatcher) work() {
+	defer d.workers.Done()
+
+	for {
+		select {
+		case item := <-d.queue:
+			d.deliver(item)
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(item delivery) {
+	retryable, err := d.send(item)
+	if err == nil {
+		d.pending.Done()
+		return
+	}
+
+	if !retryable || item.attempt >= d.options.MaxAttempts {
+		d.fail(item, err)
+		return
+	}
+
+	backoff := d.backoff(item.attempt)
+	d.logger.Warn("webhook delivery failed, retrying",
+		zap.Stringer("delivery_id", item.event.ID),
+		zap.Stringer("game_id", item.event.GameID),
+		zap.String("url", item.target.URL),
+		zap.Int("attempt", item.attempt),
+		zap.Int("max_attempts", d.options.MaxAttempts),
+		zap.Duration("backoff", backoff),
+		zap.Error(err))
+
+	d.schedule(item, backoff, err)
+}
+
+// schedule puts the delivery back in the queue after backoff.
+func (d *Dispatcher) schedule(item delivery, backoff time.Duration, cause error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		d.fail(item, fmt.Errorf("dispatcher stopped before retry: %w", cause))
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(backoff, func() {
+		d.mu.Lock()
+		delete(d.retries, timer)
+		d.mu.Unlock()
+
+		next := item
+		next.attempt++
+		select {
+		case d.queue <- next:
+		case <-d.stop:
+			d.fail(item, fmt.Errorf("dispatcher stopped before retry: %w", cause))
+		}
+	})
+	d.retries[timer] = scheduledRetry{item: item, cause: cause}
+}
+
+func (d *Dispatcher) fail(item delivery, cause error) {
+	d.options.DeadLetter.Write(item, cause)
+	d.pending.Done()
+}
+
+func (d *Dispatcher) send(item delivery) (bool, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, item.target.URL, bytes.NewReader(item.body))
+	if err != nil {
+		return false, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "tictactoe-webhooks/1.0")
+	req.Header.Set(HeaderEvent, string(item.event.Type))
+	req.Header.Set(HeaderDelivery, item.event.ID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(item.target.Secret, timestamp, item.body))
+
+	client := d.client
+	if item.trusted {
+		client = d.trusted
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return !errors.Is(err, ErrForbiddenDestination), err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("receiver responded with %s", resp.Status)
+	default:
+		return false, fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+}
+
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	backoff := d.options.InitialBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > d.options.MaxBackoff {
+		backoff = d.options.MaxBackoff
+	}
+
+	jitter := time.Duration(rand.Int64N(int64(backoff)/5 + 1))
+	return backoff - backoff/10 + jitter
+}
+
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
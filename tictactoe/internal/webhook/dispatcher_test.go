@@ -0,0 +1,309 @@
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"tictactoe/internal/domain/event"
+	"tictactoe/internal/domain/model"
+)
+
+const testSecret = "test-secret"
+
+func newTestDispatcher(t *testing.T, global []string, maxAttempts int) (*Dispatcher, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "dead-letter.log")
+	deadLetter, err := NewDeadLetterLog(path, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { deadLetter.Close() })
+
+	webhooks := make([]model.Webhook, 0, len(global))
+	for _, url := range global {
+		webhooks = append(webhooks, model.Webhook{
+			URL:    url,
+			Events: []string{string(event.GameFinished)},
+			Secret: testSecret,
+		})
+	}
+
+	dispatcher := NewDispatcher(Options{
+		Global:         webhooks,
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+		Timeout:        time.Second,
+		Workers:        1,
+		DeadLetter:     deadLetter,
+	}, zap.NewNop())
+
+	return dispatcher, path
+}
+
+func finishedEvent(webhooks ...model.Webhook) event.Event {
+	gameID := uuid.New()
+	return event.Event{
+		ID:         uuid.New(),
+		Type:       event.GameFinished,
+		GameID:     gameID,
+		Game:       &model.Game{ID: gameID, Webhooks: webhooks},
+		OccurredAt: time.Now(),
+	}
+}
+
+func closeDispatcher(t *testing.T, dispatcher *Dispatcher) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := dispatcher.Close(ctx); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+}
+
+func readDeadLetters(t *testing.T, path string) []deadLetterEntry {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var entries []deadLetterEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry deadLetterEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid dead-letter line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestDispatcherSignsDeliveries(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	deliveries := make(chan received, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		deliveries <- received{header: r.Header.Clone(), body: body}
+	}))
+	defer server.Close()
+
+	dispatcher, path := newTestDispatcher(t, []string{server.URL}, 3)
+	ev := finishedEvent()
+	dispatcher.Handle(context.Background(), ev)
+
+	select {
+	case got := <-deliveries:
+		timestamp := got.header.Get(HeaderTimestamp)
+		if want := Sign(testSecret, timestamp, got.body); got.header.Get(HeaderSignature) != want {
+			t.Errorf("%s = %q, want %q", HeaderSignature, got.header.Get(HeaderSignature), want)
+		}
+		if got.header.Get(HeaderEvent) != string(event.GameFinished) {
+			t.Errorf("%s = %q, want %q", HeaderEvent, got.header.Get(HeaderEvent), event.GameFinished)
+		}
+		if got.header.Get(HeaderDelivery) != ev.ID.String() {
+			t.Errorf("%s = %q, want %q", HeaderDelivery, got.header.Get(HeaderDelivery), ev.ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+
+	closeDispatcher(t, dispatcher)
+	if entries := readDeadLetters(t, path); len(entries) != 0 {
+		t.Errorf("dead-letter log has %d entries, want 0", len(entries))
+	}
+}
+
+func TestDispatcherRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	dispatcher, path := newTestDispatcher(t, []string{server.URL}, 5)
+	dispatcher.Handle(context.Background(), finishedEvent())
+	closeDispatcher(t, dispatcher)
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("receiver got %d requests, want 3", got)
+	}
+	if entries := readDeadLetters(t, path); len(entries) != 0 {
+		t.Errorf("dead-letter log has %d entries, want 0", len(entries))
+	}
+}
+
+func TestDispatcherDeadLettersAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	dispatcher, path := newTestDispatcher(t, []string{server.URL}, 3)
+	ev := finishedEvent()
+	dispatcher.Handle(context.Background(), ev)
+	closeDispatcher(t, dispatcher)
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("receiver got %d requests, want 3", got)
+	}
+
+	entries := readDeadLetters(t, path)
+	if len(entries) != 1 {
+		t.Fatalf("dead-letter log has %d entries, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.DeliveryID != ev.ID.String() || entry.URL != server.URL || entry.Attempts != 3 {
+		t.Errorf("dead-letter entry = %+v, want delivery %s to %s after 3 attempts", entry, ev.ID, server.URL)
+	}
+	if !strings.Contains(entry.Error, "503") {
+		t.Errorf("dead-letter error = %q, want the 503 response", entry.Error)
+	}
+}
+
+func TestDispatcherRetriesDoNotBlockWorkers(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	delivered := make(chan struct{}, 1)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered <- struct{}{}
+	}))
+	defer healthy.Close()
+
+	dispatcher, _ := newTestDispatcher(t, []string{failing.URL, healthy.URL}, 3)
+	dispatcher.options.InitialBackoff = time.Minute
+	dispatcher.options.MaxBackoff = time.Minute
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		dispatcher.Close(ctx)
+	}()
+
+	dispatcher.Handle(context.Background(), finishedEvent())
+
+	select {
+	case <-delivered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery to a healthy receiver waited for another receiver's retry")
+	}
+}
+
+func TestDispatcherRejectsInternalGameWebhooks(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	dispatcher, path := newTestDispatcher(t, nil, 3)
+	dispatcher.Handle(context.Background(), finishedEvent(model.Webhook{
+		URL:    server.URL,
+		Events: []string{string(event.GameFinished)},
+		Secret: testSecret,
+	}))
+	closeDispatcher(t, dispatcher)
+
+	if got := calls.Load(); got != 0 {
+		t.Errorf("receiver got %d requests, want 0", got)
+	}
+
+	entries := readDeadLetters(t, path)
+	if len(entries) != 1 || entries[0].Attempts != 1 {
+		t.Fatalf("dead-letter entries = %+v, want one entry after 1 attempt", entries)
+	}
+	if !strings.Contains(entries[0].Error, ErrForbiddenDestination.Error()) {
+		t.Errorf("dead-letter error = %q, want %q", entries[0].Error, ErrForbiddenDestination)
+	}
+}
+
+func TestDispatcherDoesNotFollowRedirects(t *testing.T) {
+	var calls atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer target.Close()
+
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer redirect.Close()
+
+	dispatcher, path := newTestDispatcher(t, []string{redirect.URL}, 3)
+	dispatcher.Handle(context.Background(), finishedEvent())
+	closeDispatcher(t, dispatcher)
+
+	if got := calls.Load(); got != 0 {
+		t.Errorf("redirect target got %d requests, want 0", got)
+	}
+	if entries := readDeadLetters(t, path); len(entries) != 1 || entries[0].Attempts != 1 {
+		t.Errorf("dead-letter entries = %+v, want one entry after 1 attempt", entries)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		forbidden bool
+		invalid   bool
+	}{
+		{url: "https://bot.example.com/hook"},
+		{url: "http://203.0.113.10:8080/hook"},
+		{url: "ftp://bot.example.com/hook", invalid: true},
+		{url: "/hook", invalid: true},
+		{url: "http://localhost:9100/metrics", forbidden: true},
+		{url: "http://api.localhost/", forbidden: true},
+		{url: "http://127.0.0.1/", forbidden: true},
+		{url: "http://[::1]/", forbidden: true},
+		{url: "http://[::ffff:127.0.0.1]/", forbidden: true},
+		{url: "http://0.0.0.0/", forbidden: true},
+		{url: "http://10.0.0.5/", forbidden: true},
+		{url: "http://172.16.1.1/", forbidden: true},
+		{url: "http://192.168.1.1/", forbidden: true},
+		{url: "http://169.254.169.254/latest/meta-data", forbidden: true},
+		{url: "http://[fe80::1]/", forbidden: true},
+		{url: "http://[fd00::1]/", forbidden: true},
+	}
+
+	for _, tt := range tests {
+		err := CheckURL(tt.url)
+		switch {
+		case tt.forbidden:
+			if !errors.Is(err, ErrForbiddenDestination) {
+				t.Errorf("CheckURL(%q) = %v, want %v", tt.url, err, ErrForbiddenDestination)
+			}
+		case tt.invalid:
+			if err == nil || errors.Is(err, ErrForbiddenDestination) {
+				t.Errorf("CheckURL(%q) = %v, want an invalid URL error", tt.url, err)
+			}
+		case err != nil:
+			t.Errorf("CheckURL(%q) = %v, want nil", tt.url, err)
+		}
+	}
+}
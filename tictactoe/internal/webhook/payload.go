@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"tictactoe/internal/domain/event"
+)
+
+type movePayload struct {
+	Row    int `json:"row"`
+	Col    int `json:"col"`
+	Player int `json:"player"`
+}
+
+type gamePayload struct {
+	GameID     string  `json:"game_id"`
+	OwnerID    string  `json:"owner_id"`
+	OpponentID string  `json:"opponent_id,omitempty"`
+	Mode       string  `json:"mode"`
+	Size       int     `json:"size"`
+	Field      [][]int `json:"field"`
+	Status     string  `json:"status"`
+	PlayerTurn int     `json:"player_turn"`
+}
+
+type payload struct {
+	ID         string       `json:"id"`
+	Type       string       `json:"type"`
+	GameID     string       `json:"game_id"`
+	UserID     string       `json:"user_id,omitempty"`
+	OccurredAt string       `json:"occurred_at"`
+	Game       *gamePayload `json:"game,omitempty"`
+	Move       *movePayload `json:"move,omitempty"`
+	Message    string       `json:"message,omitempty"`
+}
+
+func newPayload(ev event.Event) *payload {
+	p := &payload{
+		ID:         ev.ID.String(),
+		Type:       string(ev.Type),
+		GameID:     ev.GameID.String(),
+		OccurredAt: ev.OccurredAt.Format(time.RFC3339Nano),
+		Message:    ev.Message,
+	}
+
+	if ev.UserID != uuid.Nil {
+		p.UserID = ev.UserID.String()
+	}
+
+	if game := ev.Game; game != nil {
+		p.Game = &gamePayload{
+			GameID:     game.ID.String(),
+			OwnerID:    game.OwnerID.String(),
+			Mode:       game.Mode,
+			Size:       game.Size,
+			Field:      game.Field,
+			Status:     game.State,
+			PlayerTurn: game.PlayerTurn,
+		}
+		if game.OpponentID != uuid.Nil {
+			p.Game.OpponentID = game.OpponentID.String()
+		}
+	}
+
+	if move := ev.Move; move != nil {
+		p.Move = &movePayload{
+			Row:    move.Row,
+			Col:    move.Col,
+			Player: move.Player,
+		}
+	}
+
+	return p
+}